@@ -0,0 +1,270 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/redis/rueidis/rueidisprob"
+	"github.com/twmb/murmur3"
+)
+
+// 计数布隆过滤器的每个位置为 4 位计数器，计数达到 15 后不再变化，避免溢出导致误删
+var countingBloomAddLua = `
+local filter, counter = KEYS[1], KEYS[2]
+local k = tonumber(ARGV[1])
+for i = 2, #ARGV do
+	local offset = tonumber(ARGV[i]) * 4
+	local v = redis.call('BITFIELD', filter, 'GET', 'u4', offset)[1]
+	if v < 15 then
+		redis.call('BITFIELD', filter, 'SET', 'u4', offset, v + 1)
+	end
+end
+return redis.call('INCRBY', counter, (#ARGV - 1) / k)
+`
+
+// 只删除所有计数器均大于 0 的元素，返回删除的数量
+var countingBloomRemoveLua = `
+local filter, counter = KEYS[1], KEYS[2]
+local k = tonumber(ARGV[1])
+local removed = 0
+for i = 2, #ARGV, k do
+	local exists = true
+	for j = i, i + k - 1 do
+		if redis.call('BITFIELD', filter, 'GET', 'u4', tonumber(ARGV[j]) * 4)[1] == 0 then
+			exists = false
+			break
+		end
+	end
+	if exists then
+		for j = i, i + k - 1 do
+			local offset = tonumber(ARGV[j]) * 4
+			local v = redis.call('BITFIELD', filter, 'GET', 'u4', offset)[1]
+			if v > 0 and v < 15 then
+				redis.call('BITFIELD', filter, 'SET', 'u4', offset, v - 1)
+			end
+		end
+		removed = removed + 1
+	end
+end
+if removed > 0 then
+	redis.call('DECRBY', counter, removed)
+end
+return removed
+`
+
+var countingBloomExistsLua = `
+local filter = KEYS[1]
+local k = tonumber(ARGV[1])
+local result = {}
+for i = 2, #ARGV, k do
+	local exists = 1
+	for j = i, i + k - 1 do
+		if redis.call('BITFIELD', filter, 'GET', 'u4', tonumber(ARGV[j]) * 4)[1] == 0 then
+			exists = 0
+			break
+		end
+	end
+	table.insert(result, exists)
+end
+return result
+`
+
+var ErrBloomFilterSizeTooLarge = errors.New("bloom filter size is too large")
+
+const (
+	countingBloomKeyFormat        = "cbf:{%s}"
+	countingBloomCounterKeyFormat = "cbf:{%s}:c"
+	// 计数器的位数，Redis 字符串最大为 2^32 位
+	countingBloomCounterBits = 4
+	countingBloomMaxSize     = 1 << 32 / countingBloomCounterBits
+)
+
+// CountingBloomFilter based on Redis Bitmaps with 4-bit counters.
+// CountingBloomFilter uses 128-bit murmur3 hash function.
+// Unlike BloomFilter, an item can be removed after being added.
+type CountingBloomFilter interface {
+	// Add adds an item to the Counting Bloom Filter.
+	Add(ctx context.Context, key string) error
+
+	// AddMulti adds one or more items to the Counting Bloom Filter.
+	// NOTE: If keys are too many, it can block the Redis server for a long time.
+	AddMulti(ctx context.Context, keys []string) error
+
+	// Remove removes an item from the Counting Bloom Filter. It is a no-op if the item does not exist.
+	// NOTE: Removing an item which was never added may remove other items because of the hash collisions.
+	Remove(ctx context.Context, key string) error
+
+	// RemoveMulti removes one or more items from the Counting Bloom Filter.
+	// NOTE: If keys are too many, it can block the Redis server for a long time.
+	RemoveMulti(ctx context.Context, keys []string) error
+
+	// Exists checks if an item is in the Counting Bloom Filter.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// ExistsMulti checks if one or more items are in the Counting Bloom Filter.
+	// Returns a slice of bool values where each bool indicates whether the corresponding key was found.
+	ExistsMulti(ctx context.Context, keys []string) ([]bool, error)
+
+	// Reset resets the Counting Bloom Filter.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the Counting Bloom Filter.
+	Delete(ctx context.Context) error
+
+	// Count returns count of items in Counting Bloom Filter.
+	Count(ctx context.Context) (uint64, error)
+}
+
+type countingBloomFilter struct {
+	c              *client
+	name           string
+	counter        string
+	size           uint64
+	hashIterations uint
+
+	readOnly     bool
+	addScript    Scripter
+	removeScript Scripter
+	existsScript Scripter
+}
+
+func newCountingBloomFilter(c *client, name string, expectedNumberOfItems uint, falsePositiveRate float64, opts ...BloomOption) (CountingBloomFilter, error) {
+	if c.version.LessThan(mustNewSemVersion(bloomFilterROVersion)) {
+		opts = append(opts, WithBloomOptionEnableReadOperation(false))
+	}
+	if len(name) == 0 {
+		return nil, rueidisprob.ErrEmptyName
+	}
+	if falsePositiveRate <= 0 {
+		return nil, rueidisprob.ErrFalsePositiveRateLessThanEqualZero
+	}
+	if falsePositiveRate > 1 {
+		return nil, rueidisprob.ErrFalsePositiveRateGreaterThanOne
+	}
+	size := uint64(math.Ceil(-float64(expectedNumberOfItems) * math.Log(falsePositiveRate) / math.Pow(math.Log(2), 2)))
+	if size == 0 {
+		return nil, rueidisprob.ErrBitsSizeZero
+	}
+	if size > countingBloomMaxSize {
+		return nil, ErrBloomFilterSizeTooLarge
+	}
+	hashIterations := uint(math.Round(float64(size) / float64(expectedNumberOfItems) * math.Log(2)))
+	if hashIterations == 0 {
+		hashIterations = 1
+	}
+	cc := newBloomOptions(opts...)
+	existsLua := countingBloomExistsLua
+	if cc.GetEnableReadOperation() {
+		existsLua = readOnlyBitField(existsLua)
+	}
+	return &countingBloomFilter{
+		c:              c,
+		name:           fmt.Sprintf(countingBloomKeyFormat, name),
+		counter:        fmt.Sprintf(countingBloomCounterKeyFormat, name),
+		size:           size,
+		hashIterations: hashIterations,
+		readOnly:       cc.GetEnableReadOperation(),
+		addScript:      c.CreateScript(countingBloomAddLua),
+		removeScript:   c.CreateScript(countingBloomRemoveLua),
+		existsScript:   c.CreateScript(existsLua),
+	}, nil
+}
+
+// args 返回脚本参数，第一个为 hash 函数的数量，之后为每个元素的计数器序号
+func (f *countingBloomFilter) args(keys []string) []any {
+	args := make([]any, 0, 1+len(keys)*int(f.hashIterations))
+	args = append(args, f.hashIterations)
+	for _, key := range keys {
+		h1, h2 := murmur3.Sum128([]byte(key))
+		for i := uint(0); i < f.hashIterations; i++ {
+			args = append(args, strconv.FormatUint((h1+uint64(i)*h2)%f.size, 10))
+		}
+	}
+	return args
+}
+
+func (f *countingBloomFilter) Add(ctx context.Context, key string) error {
+	return f.AddMulti(ctx, []string{key})
+}
+
+func (f *countingBloomFilter) AddMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return f.addScript.Run(ctx, []string{f.name, f.counter}, f.args(keys)...).Err()
+}
+
+func (f *countingBloomFilter) Remove(ctx context.Context, key string) error {
+	return f.RemoveMulti(ctx, []string{key})
+}
+
+func (f *countingBloomFilter) RemoveMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return f.removeScript.Run(ctx, []string{f.name, f.counter}, f.args(keys)...).Err()
+}
+
+func (f *countingBloomFilter) Exists(ctx context.Context, key string) (bool, error) {
+	exists, err := f.ExistsMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+	return exists[0], nil
+}
+
+func (f *countingBloomFilter) ExistsMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if f.readOnly {
+		return int64SliceToBools(f.existsScript.RunRO(ctx, []string{f.name}, f.args(keys)...).Int64Slice())
+	}
+	return int64SliceToBools(f.existsScript.Run(ctx, []string{f.name}, f.args(keys)...).Int64Slice())
+}
+
+func (f *countingBloomFilter) Reset(ctx context.Context) error {
+	if err := f.c.Set(ctx, f.name, "", 0).Err(); err != nil {
+		return err
+	}
+	return f.c.Set(ctx, f.counter, 0, 0).Err()
+}
+
+func (f *countingBloomFilter) Delete(ctx context.Context) error {
+	if err := f.c.Del(ctx, f.name).Err(); err != nil {
+		return err
+	}
+	return f.c.Del(ctx, f.counter).Err()
+}
+
+func (f *countingBloomFilter) Count(ctx context.Context) (uint64, error) {
+	return countOf(ctx, f.c, f.counter)
+}
+
+// countOf 读取过滤器的计数，不存在时返回 0
+func countOf(ctx context.Context, c *client, key string) (uint64, error) {
+	n, err := c.Get(ctx, key).Uint64()
+	if IsNil(err) {
+		return 0, nil
+	}
+	return n, err
+}
+
+func int64SliceToBools(vals []int64, err error) ([]bool, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := make([]bool, len(vals))
+	for i, v := range vals {
+		result[i] = v == 1
+	}
+	return result, nil
+}
+
+// NewCountingBloomFilter 新建一个计数布隆过滤器，支持删除元素
+func (c *client) NewCountingBloomFilter(name string, expectedNumberOfItems uint, falsePositiveRate float64, opts ...BloomOption) (CountingBloomFilter, error) {
+	return newCountingBloomFilter(c, name, expectedNumberOfItems, falsePositiveRate, opts...)
+}
@@ -0,0 +1,145 @@
+package redisson
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redis/rueidis/rueidisprob"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCountingBloomFilter(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("counting bloom filter", t, func() {
+		_, err := c.NewCountingBloomFilter("", 100, 0.01)
+		So(err, ShouldEqual, rueidisprob.ErrEmptyName)
+
+		bf, err := c.NewCountingBloomFilter("mock_counting_bloom", 100, 0.01)
+		So(err, ShouldBeNil)
+		So(bf.Delete(ctx), ShouldBeNil)
+
+		var keys []string
+		for i := 0; i < 50; i++ {
+			keys = append(keys, fmt.Sprintf("item-%d", i))
+		}
+		So(bf.Add(ctx, keys[0]), ShouldBeNil)
+		So(bf.AddMulti(ctx, keys[1:]), ShouldBeNil)
+		count, err := bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 50)
+		exists, err := bf.ExistsMulti(ctx, keys)
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+
+		So(bf.Remove(ctx, keys[0]), ShouldBeNil)
+		So(bf.RemoveMulti(ctx, keys[1:10]), ShouldBeNil)
+		ok, err := bf.Exists(ctx, keys[0])
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+		count, err = bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 40)
+		exists, err = bf.ExistsMulti(ctx, keys[10:])
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+
+		// 删除不存在的元素不影响计数
+		So(bf.Remove(ctx, keys[0]), ShouldBeNil)
+		count, err = bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 40)
+
+		So(bf.Reset(ctx), ShouldBeNil)
+		count, err = bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 0)
+		So(bf.Delete(ctx), ShouldBeNil)
+	})
+}
+
+func TestCuckooFilter(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("cuckoo filter", t, func() {
+		_, err := c.NewCuckooFilter("mock_cuckoo", 100, WithCuckooOptionBucketSize(0))
+		So(err, ShouldEqual, ErrInvalidCuckooBucketSize)
+
+		cf, err := c.NewCuckooFilter("mock_cuckoo", 100)
+		So(err, ShouldBeNil)
+		So(cf.Delete(ctx), ShouldBeNil)
+
+		var keys []string
+		for i := 0; i < 100; i++ {
+			keys = append(keys, fmt.Sprintf("item-%d", i))
+		}
+		So(cf.Add(ctx, keys[0]), ShouldBeNil)
+		So(cf.AddMulti(ctx, keys[1:]), ShouldBeNil)
+		count, err := cf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 100)
+		exists, err := cf.ExistsMulti(ctx, keys)
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+
+		So(cf.Remove(ctx, keys[0]), ShouldBeNil)
+		So(cf.RemoveMulti(ctx, keys[1:50]), ShouldBeNil)
+		count, err = cf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 50)
+		exists, err = cf.ExistsMulti(ctx, keys[50:])
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+		ok, err := cf.Exists(ctx, keys[0])
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+
+		So(cf.Reset(ctx), ShouldBeNil)
+		count, err = cf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 0)
+	})
+
+	Convey("cuckoo filter full", t, func() {
+		cf, err := c.NewCuckooFilter("mock_cuckoo_full", 4, WithCuckooOptionBucketSize(2), WithCuckooOptionMaxKicks(10))
+		So(err, ShouldBeNil)
+		So(cf.Delete(ctx), ShouldBeNil)
+
+		var full error
+		var added []string
+		for i := 0; i < 20 && full == nil; i++ {
+			key := fmt.Sprintf("item-%d", i)
+			if full = cf.Add(ctx, key); full == nil {
+				added = append(added, key)
+			}
+		}
+		So(full, ShouldEqual, ErrCuckooFilterFull)
+		count, err := cf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, len(added))
+		// 插入失败时踢出的指纹会被恢复
+		exists, err := cf.ExistsMulti(ctx, added)
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+		So(cf.Delete(ctx), ShouldBeNil)
+	})
+}
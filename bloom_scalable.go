@@ -0,0 +1,342 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/redis/rueidis/rueidisprob"
+)
+
+// 初始化并读取可扩展布隆过滤器的元数据，已存在的元数据不会被覆盖，保证所有进程使用相同的参数
+var scalableBloomMetaLua = `
+local meta = KEYS[1]
+redis.call('HSETNX', meta, 'capacity', ARGV[1])
+redis.call('HSETNX', meta, 'error_rate', ARGV[2])
+redis.call('HSETNX', meta, 'growth', ARGV[3])
+redis.call('HSETNX', meta, 'ratio', ARGV[4])
+redis.call('HSETNX', meta, 'layers', 1)
+return redis.call('HMGET', meta, 'capacity', 'error_rate', 'growth', 'ratio', 'layers')
+`
+
+// 当前层数与预期一致时增加一层，避免多个进程同时扩容
+var growScalableBloomLua = `
+local meta = KEYS[1]
+local layers = tonumber(redis.call('HGET', meta, 'layers') or '0')
+if layers == tonumber(ARGV[1]) then
+	layers = redis.call('HINCRBY', meta, 'layers', 1)
+end
+return layers
+`
+
+var (
+	ErrInvalidBloomGrowthFactor    = errors.New("bloom filter growth factor must be greater than 0")
+	ErrInvalidBloomTighteningRatio = errors.New("bloom filter tightening ratio must be in (0, 1)")
+)
+
+const (
+	scalableBloomMetaKeyFormat  = "sbf:{%s}"
+	scalableBloomLayerKeyFormat = "%s:sbf:%d"
+)
+
+// scalableBloomMeta 可扩展布隆过滤器的元数据
+type scalableBloomMeta struct {
+	capacity  uint
+	errorRate float64
+	growth    uint
+	ratio     float64
+	layers    int
+}
+
+// scalableBloomFilter 可扩展布隆过滤器，由多层 rueidisprob.BloomFilter 组成
+// 最新一层的数量达到容量后新增一层，第 i 层的容量为 capacity * growth^i，误判率为 errorRate * (1 - ratio) * ratio^i，总误判率不超过 errorRate
+// 层数等元数据保存在 Redis 中，所有进程看到相同的层
+type scalableBloomFilter struct {
+	c    *client
+	spec BloomOptionsVisitor
+	name string
+	meta string
+
+	capacity  uint
+	errorRate float64
+
+	metaScript Scripter
+	growScript Scripter
+
+	mu      sync.Mutex
+	filters []BloomFilter
+}
+
+func newScalableBloomFilter(c *client, name string, initialCapacity uint, falsePositiveRate float64, opts ...BloomOption) (BloomFilter, error) {
+	if c.version.LessThan(mustNewSemVersion(bloomFilterROVersion)) {
+		opts = append(opts, WithBloomOptionEnableReadOperation(false))
+	}
+	if len(name) == 0 {
+		return nil, rueidisprob.ErrEmptyName
+	}
+	if falsePositiveRate <= 0 {
+		return nil, rueidisprob.ErrFalsePositiveRateLessThanEqualZero
+	}
+	if falsePositiveRate > 1 {
+		return nil, rueidisprob.ErrFalsePositiveRateGreaterThanOne
+	}
+	if initialCapacity == 0 {
+		return nil, rueidisprob.ErrBitsSizeZero
+	}
+	spec := newBloomOptions(opts...)
+	if spec.GetGrowthFactor() == 0 {
+		return nil, ErrInvalidBloomGrowthFactor
+	}
+	if ratio := spec.GetTighteningRatio(); ratio <= 0 || ratio >= 1 {
+		return nil, ErrInvalidBloomTighteningRatio
+	}
+	return &scalableBloomFilter{
+		c:          c,
+		spec:       spec,
+		name:       name,
+		meta:       fmt.Sprintf(scalableBloomMetaKeyFormat, name),
+		capacity:   initialCapacity,
+		errorRate:  falsePositiveRate,
+		metaScript: c.CreateScript(scalableBloomMetaLua),
+		growScript: c.CreateScript(growScalableBloomLua),
+	}, nil
+}
+
+// load 读取元数据并返回所有层，最新的一层在最后，init 为 true 时初始化不存在的元数据
+// 只读操作不初始化元数据，元数据不存在时使用本地的参数，此时只有第一层
+func (b *scalableBloomFilter) load(ctx context.Context, init bool) (m scalableBloomMeta, filters []BloomFilter, err error) {
+	var vals []string
+	if init {
+		vals, err = b.metaScript.Run(ctx, []string{b.meta}, b.capacity, strconv.FormatFloat(b.errorRate, 'g', -1, 64), b.spec.GetGrowthFactor(), strconv.FormatFloat(b.spec.GetTighteningRatio(), 'g', -1, 64)).StringSlice()
+	} else {
+		vals, err = b.readMeta(ctx)
+	}
+	if err != nil {
+		return
+	}
+	if m, err = parseScalableBloomMeta(vals); err != nil {
+		return
+	}
+	filters, err = b.filtersOf(m)
+	return
+}
+
+// readMeta 使用 HMGET 读取元数据，不存在时返回本地的参数
+func (b *scalableBloomFilter) readMeta(ctx context.Context) ([]string, error) {
+	res, err := b.c.HMGet(ctx, b.meta, "capacity", "error_rate", "growth", "ratio", "layers").Result()
+	if err != nil {
+		return nil, err
+	}
+	vals := []string{strconv.FormatUint(uint64(b.capacity), 10), strconv.FormatFloat(b.errorRate, 'g', -1, 64),
+		strconv.FormatUint(uint64(b.spec.GetGrowthFactor()), 10), strconv.FormatFloat(b.spec.GetTighteningRatio(), 'g', -1, 64), "1"}
+	for i, v := range res {
+		if str, ok := v.(string); ok && i < len(vals) {
+			vals[i] = str
+		}
+	}
+	return vals, nil
+}
+
+func (b *scalableBloomFilter) layers(ctx context.Context) ([]BloomFilter, error) {
+	_, filters, err := b.load(ctx, false)
+	return filters, err
+}
+
+func parseScalableBloomMeta(vals []string) (m scalableBloomMeta, err error) {
+	if len(vals) != 5 {
+		return m, fmt.Errorf("unexpected scalable bloom filter meta %v", vals)
+	}
+	var capacity, growth uint64
+	if capacity, err = strconv.ParseUint(vals[0], 10, 64); err != nil {
+		return
+	}
+	if m.errorRate, err = strconv.ParseFloat(vals[1], 64); err != nil {
+		return
+	}
+	if growth, err = strconv.ParseUint(vals[2], 10, 64); err != nil {
+		return
+	}
+	if m.ratio, err = strconv.ParseFloat(vals[3], 64); err != nil {
+		return
+	}
+	if m.layers, err = strconv.Atoi(vals[4]); err != nil {
+		return
+	}
+	m.capacity, m.growth = uint(capacity), uint(growth)
+	return
+}
+
+// filtersOf 按元数据创建缺少的层
+func (b *scalableBloomFilter) filtersOf(m scalableBloomMeta) ([]BloomFilter, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if m.layers < len(b.filters) {
+		// 其他进程执行了 Reset
+		b.filters = b.filters[:m.layers]
+	}
+	for i := len(b.filters); i < m.layers; i++ {
+		f, err := rueidisprob.NewBloomFilter(b.c.cmd, b.layerName(i), layerCapacity(m, i), m.errorRate*(1-m.ratio)*math.Pow(m.ratio, float64(i)),
+			rueidisprob.WithEnableReadOperation(b.spec.GetEnableReadOperation()))
+		if err != nil {
+			return nil, err
+		}
+		b.filters = append(b.filters, f)
+	}
+	return b.filters[:m.layers:m.layers], nil
+}
+
+func layerCapacity(m scalableBloomMeta, i int) uint {
+	return uint(float64(m.capacity) * math.Pow(float64(m.growth), float64(i)))
+}
+
+func (b *scalableBloomFilter) layerName(i int) string {
+	return fmt.Sprintf(scalableBloomLayerKeyFormat, b.name, i)
+}
+
+func (b *scalableBloomFilter) Add(ctx context.Context, key string) error {
+	return b.AddMulti(ctx, []string{key})
+}
+
+func (b *scalableBloomFilter) AddMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	m, filters, err := b.load(ctx, true)
+	if err != nil {
+		return err
+	}
+	// 已存在于任意一层的元素不再添加，避免重复计数
+	exists, err := b.existsMulti(ctx, filters, keys)
+	if err != nil {
+		return err
+	}
+	var adds []string
+	for i, key := range keys {
+		if !exists[i] {
+			adds = append(adds, key)
+		}
+	}
+	if len(adds) == 0 {
+		return nil
+	}
+	current := filters[len(filters)-1]
+	if err = current.AddMulti(ctx, adds); err != nil {
+		return err
+	}
+	count, err := current.Count(ctx)
+	if err != nil {
+		return err
+	}
+	if count >= uint64(layerCapacity(m, len(filters)-1)) {
+		return b.growScript.Run(ctx, []string{b.meta}, len(filters)).Err()
+	}
+	return nil
+}
+
+func (b *scalableBloomFilter) Exists(ctx context.Context, key string) (bool, error) {
+	exists, err := b.ExistsMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+	return exists[0], nil
+}
+
+func (b *scalableBloomFilter) ExistsMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	filters, err := b.layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.existsMulti(ctx, filters, keys)
+}
+
+// existsMulti 从最新的一层开始检查，所有元素都存在时提前返回
+func (b *scalableBloomFilter) existsMulti(ctx context.Context, filters []BloomFilter, keys []string) ([]bool, error) {
+	result := make([]bool, len(keys))
+	for i := len(filters) - 1; i >= 0; i-- {
+		exists, err := filters[i].ExistsMulti(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		all := true
+		for j, v := range exists {
+			result[j] = result[j] || v
+			all = all && result[j]
+		}
+		if all {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Reset 清空第一层并删除其余的层
+func (b *scalableBloomFilter) Reset(ctx context.Context) error {
+	filters, err := b.layers(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(filters) - 1; i > 0; i-- {
+		if err = filters[i].Delete(ctx); err != nil {
+			return err
+		}
+	}
+	if err = filters[0].Reset(ctx); err != nil {
+		return err
+	}
+	if err = b.c.HSet(ctx, b.meta, "layers", 1).Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.filters = b.filters[:1]
+	b.mu.Unlock()
+	return nil
+}
+
+// Delete 删除所有的层以及元数据
+func (b *scalableBloomFilter) Delete(ctx context.Context) error {
+	filters, err := b.layers(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(filters) - 1; i >= 0; i-- {
+		if err = filters[i].Delete(ctx); err != nil {
+			return err
+		}
+	}
+	if err = b.c.Del(ctx, b.meta).Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	b.filters = nil
+	b.mu.Unlock()
+	return nil
+}
+
+// Count 所有层的数量之和
+func (b *scalableBloomFilter) Count(ctx context.Context) (uint64, error) {
+	filters, err := b.layers(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	for _, f := range filters {
+		n, err := f.Count(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// NewScalableBloomFilter 新建一个可扩展布隆过滤器，数量超过 initialCapacity 后自动新增容量更大、误判率更低的层
+// 相同名称的过滤器使用第一次创建时保存在 Redis 中的参数
+func (c *client) NewScalableBloomFilter(name string, initialCapacity uint, falsePositiveRate float64, opts ...BloomOption) (BloomFilter, error) {
+	return newScalableBloomFilter(c, name, initialCapacity, falsePositiveRate, opts...)
+}
@@ -0,0 +1,88 @@
+package redisson
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redis/rueidis/rueidisprob"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScalableBloomFilter(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("scalable bloom filter options", t, func() {
+		_, err := c.NewScalableBloomFilter("", 10, 0.01)
+		So(err, ShouldEqual, rueidisprob.ErrEmptyName)
+		_, err = c.NewScalableBloomFilter("invalid", 10, 0)
+		So(err, ShouldEqual, rueidisprob.ErrFalsePositiveRateLessThanEqualZero)
+		_, err = c.NewScalableBloomFilter("invalid", 10, 0.01, WithBloomOptionGrowthFactor(0))
+		So(err, ShouldEqual, ErrInvalidBloomGrowthFactor)
+		_, err = c.NewScalableBloomFilter("invalid", 10, 0.01, WithBloomOptionTighteningRatio(1))
+		So(err, ShouldEqual, ErrInvalidBloomTighteningRatio)
+	})
+
+	Convey("scalable bloom filter grows", t, func() {
+		bf, err := c.NewScalableBloomFilter("mock_scalable_bloom", 10, 0.01)
+		So(err, ShouldBeNil)
+		So(bf.Delete(ctx), ShouldBeNil)
+
+		// 只读操作不会初始化元数据
+		exist, err := bf.Exists(ctx, "item-0")
+		So(err, ShouldBeNil)
+		So(exist, ShouldBeFalse)
+		n, err := bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 0)
+		So(c.Exists(ctx, "sbf:{mock_scalable_bloom}").Val(), ShouldEqual, 0)
+
+		var keys []string
+		for i := 0; i < 50; i++ {
+			keys = append(keys, fmt.Sprintf("item-%d", i))
+		}
+		for _, key := range keys[:20] {
+			So(bf.Add(ctx, key), ShouldBeNil)
+		}
+		So(bf.AddMulti(ctx, keys[20:]), ShouldBeNil)
+		// 重复添加不会重复计数
+		So(bf.AddMulti(ctx, keys[:10]), ShouldBeNil)
+
+		layers, err := c.HGet(ctx, "sbf:{mock_scalable_bloom}", "layers").Int64()
+		So(err, ShouldBeNil)
+		So(layers, ShouldBeGreaterThan, 1)
+
+		count, err := bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldBeBetweenOrEqual, 45, 50)
+
+		exists, err := bf.ExistsMulti(ctx, keys)
+		So(err, ShouldBeNil)
+		for _, v := range exists {
+			So(v, ShouldBeTrue)
+		}
+
+		// 其他进程使用 Redis 中保存的参数以及层
+		other, err := c.NewScalableBloomFilter("mock_scalable_bloom", 1000, 0.1)
+		So(err, ShouldBeNil)
+		ok, err := other.Exists(ctx, keys[49])
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		count1, err := other.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count1, ShouldEqual, count)
+
+		So(other.Reset(ctx), ShouldBeNil)
+		count, err = bf.Count(ctx)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 0)
+		ok, err = bf.Exists(ctx, keys[0])
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+		So(bf.Delete(ctx), ShouldBeNil)
+	})
+}
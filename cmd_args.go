@@ -77,6 +77,7 @@ type (
 	FilterBy                   = rueidiscompat.FilterBy
 	KeyFlags                   = rueidiscompat.KeyFlags
 	Message                    = rueidis.PubSubMessage
+	Subscription               = rueidis.PubSubSubscription
 	Completed                  = rueidis.Completed
 	Builder                    = rueidis.Builder
 	RedisResult                = rueidis.RedisResult
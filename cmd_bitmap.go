@@ -84,15 +84,15 @@ type BitmapCacheCmdable interface {
 
 func (c *client) BitCount(ctx context.Context, key string, bc *BitCount) IntCmd {
 	if bc == nil || bc.Unit == "" {
-		ctx = c.handler.before(ctx, CommandBitCount)
+		ctx = c.handler.beforeWithKeys(ctx, CommandBitCount, func() []string { return []string{key} })
 	} else {
 		switch strings.ToUpper(bc.Unit) {
 		case BYTE:
-			ctx = c.handler.before(ctx, CommandBitCountByte)
+			ctx = c.handler.beforeWithKeys(ctx, CommandBitCountByte, func() []string { return []string{key} })
 		case BIT:
-			ctx = c.handler.before(ctx, CommandBitCountBit)
+			ctx = c.handler.beforeWithKeys(ctx, CommandBitCountBit, func() []string { return []string{key} })
 		default:
-			ctx = c.handler.before(ctx, CommandBitCount)
+			ctx = c.handler.beforeWithKeys(ctx, CommandBitCount, func() []string { return []string{key} })
 		}
 	}
 	var r IntCmd
@@ -106,7 +106,7 @@ func (c *client) BitCount(ctx context.Context, key string, bc *BitCount) IntCmd
 }
 
 func (c *client) BitField(ctx context.Context, key string, args ...any) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandBitField)
+	ctx = c.handler.beforeWithKeys(ctx, CommandBitField, func() []string { return []string{key} })
 	r := c.adapter.BitField(ctx, key, args...)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -141,7 +141,7 @@ func (c *client) BitOpNot(ctx context.Context, destKey string, key string) IntCm
 }
 
 func (c *client) BitPos(ctx context.Context, key string, bit int64, pos ...int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandBitPos)
+	ctx = c.handler.beforeWithKeys(ctx, CommandBitPos, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.BitPosCompleted(key, bit, pos...)))
@@ -153,7 +153,7 @@ func (c *client) BitPos(ctx context.Context, key string, bit int64, pos ...int64
 }
 
 func (c *client) BitPosSpan(ctx context.Context, key string, bit, start, end int64, span string) IntCmd {
-	ctx = c.handler.before(ctx, CommandBitPosSpan)
+	ctx = c.handler.beforeWithKeys(ctx, CommandBitPosSpan, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.BitPosSpanCompleted(key, bit, start, end, span)))
@@ -165,7 +165,7 @@ func (c *client) BitPosSpan(ctx context.Context, key string, bit, start, end int
 }
 
 func (c *client) GetBit(ctx context.Context, key string, offset int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandGetBit)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGetBit, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.GetBitCompleted(key, offset)))
@@ -177,7 +177,7 @@ func (c *client) GetBit(ctx context.Context, key string, offset int64) IntCmd {
 }
 
 func (c *client) SetBit(ctx context.Context, key string, offset int64, value int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandSetBit)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSetBit, func() []string { return []string{key} })
 	r := c.adapter.SetBit(ctx, key, offset, value)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -415,7 +415,7 @@ func (c *client) Del(ctx context.Context, keys ...string) IntCmd {
 }
 
 func (c *client) Dump(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandDump)
+	ctx = c.handler.beforeWithKeys(ctx, CommandDump, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.DumpCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -433,77 +433,77 @@ func (c *client) Exists(ctx context.Context, keys ...string) IntCmd {
 }
 
 func (c *client) Expire(ctx context.Context, key string, seconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpire)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpire, func() []string { return []string{key} })
 	r := c.adapter.Expire(ctx, key, seconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireNX(ctx context.Context, key string, seconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireNX, func() []string { return []string{key} })
 	r := c.adapter.ExpireNX(ctx, key, seconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireXX(ctx context.Context, key string, seconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireXX, func() []string { return []string{key} })
 	r := c.adapter.ExpireXX(ctx, key, seconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireGT(ctx context.Context, key string, seconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireGT, func() []string { return []string{key} })
 	r := c.adapter.ExpireGT(ctx, key, seconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireLT(ctx context.Context, key string, seconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireLT, func() []string { return []string{key} })
 	r := c.adapter.ExpireLT(ctx, key, seconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireAt(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireAt)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireAt, func() []string { return []string{key} })
 	r := c.adapter.ExpireAt(ctx, key, tm)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireAtNX(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireAtNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireAtNX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.ExpireAtNXCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireAtXX(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireAtXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireAtXX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.ExpireAtXXCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireAtGT(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireAtGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireAtGT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.ExpireAtGTCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireAtLT(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandExpireAtLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireAtLT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.ExpireAtLTCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ExpireTime(ctx context.Context, key string) DurationCmd {
-	ctx = c.handler.before(ctx, CommandExpireTime)
+	ctx = c.handler.beforeWithKeys(ctx, CommandExpireTime, func() []string { return []string{key} })
 	r := c.adapter.ExpireTime(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -524,119 +524,119 @@ func (c *client) Migrate(ctx context.Context, host string, port int64, key strin
 }
 
 func (c *client) Move(ctx context.Context, key string, db int64) BoolCmd {
-	ctx = c.handler.before(ctx, CommandMove)
+	ctx = c.handler.beforeWithKeys(ctx, CommandMove, func() []string { return []string{key} })
 	r := c.adapter.Move(ctx, key, db)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ObjectRefCount(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandObjectRefCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandObjectRefCount, func() []string { return []string{key} })
 	r := c.adapter.ObjectRefCount(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ObjectEncoding(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandObjectEncoding)
+	ctx = c.handler.beforeWithKeys(ctx, CommandObjectEncoding, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.ObjectEncodingCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ObjectIdleTime(ctx context.Context, key string) DurationCmd {
-	ctx = c.handler.before(ctx, CommandObjectIdleTime)
+	ctx = c.handler.beforeWithKeys(ctx, CommandObjectIdleTime, func() []string { return []string{key} })
 	r := c.adapter.ObjectIdleTime(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) Persist(ctx context.Context, key string) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPersist)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPersist, func() []string { return []string{key} })
 	r := c.adapter.Persist(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpire(ctx context.Context, key string, milliseconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpire)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpire, func() []string { return []string{key} })
 	r := c.adapter.PExpire(ctx, key, milliseconds)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireNX(ctx context.Context, key string, milliseconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireNX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireNXCompleted(key, milliseconds)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireXX(ctx context.Context, key string, milliseconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireXX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireXXCompleted(key, milliseconds)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireGT(ctx context.Context, key string, milliseconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireGT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireGTCompleted(key, milliseconds)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireLT(ctx context.Context, key string, milliseconds time.Duration) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireLT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireLTCompleted(key, milliseconds)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireAt(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAt)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAt, func() []string { return []string{key} })
 	r := c.adapter.PExpireAt(ctx, key, tm)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireAtNX(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAtNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAtNX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireAtNXCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireAtXX(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAtXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAtXX, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireAtXXCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireAtGT(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAtGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAtGT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireAtGTCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireAtLT(ctx context.Context, key string, tm time.Time) BoolCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAtLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAtLT, func() []string { return []string{key} })
 	r := newBoolCmd(c.Do(ctx, c.builder.PExpireAtLTCompleted(key, tm)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PExpireTime(ctx context.Context, key string) DurationCmd {
-	ctx = c.handler.before(ctx, CommandPExpireAt)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPExpireAt, func() []string { return []string{key} })
 	r := c.adapter.PExpireTime(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) PTTL(ctx context.Context, key string) DurationCmd {
-	ctx = c.handler.before(ctx, CommandPTTL)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPTTL, func() []string { return []string{key} })
 	var r DurationCmd
 	if c.ttl > 0 {
 		r = newDurationCmd(c.Do(ctx, c.builder.PTTLCompleted(key)), time.Millisecond)
@@ -669,14 +669,14 @@ func (c *client) RandomKey(ctx context.Context) StringCmd {
 }
 
 func (c *client) Restore(ctx context.Context, key string, ttl time.Duration, value string) StatusCmd {
-	ctx = c.handler.before(ctx, CommandRestore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandRestore, func() []string { return []string{key} })
 	r := c.adapter.Restore(ctx, key, ttl, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) RestoreReplace(ctx context.Context, key string, ttl time.Duration, value string) StatusCmd {
-	ctx = c.handler.before(ctx, CommandRestoreReplace)
+	ctx = c.handler.beforeWithKeys(ctx, CommandRestoreReplace, func() []string { return []string{key} })
 	r := c.adapter.RestoreReplace(ctx, key, ttl, value)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -697,7 +697,7 @@ func (c *client) ScanType(ctx context.Context, cursor uint64, match string, coun
 }
 
 func (c *client) Sort(ctx context.Context, key string, sort Sort) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandSort)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSort, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.SortCompleted(key, sort)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -711,14 +711,14 @@ func (c *client) SortStore(ctx context.Context, key, store string, sort Sort) In
 }
 
 func (c *client) SortInterfaces(ctx context.Context, key string, sort Sort) SliceCmd {
-	ctx = c.handler.before(ctx, CommandSort)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSort, func() []string { return []string{key} })
 	r := c.adapter.SortInterfaces(ctx, key, sort)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) SortRO(ctx context.Context, key string, sort Sort) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandSortRO)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSortRO, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.SortROCompleted(key, sort)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -732,7 +732,7 @@ func (c *client) Touch(ctx context.Context, keys ...string) IntCmd {
 }
 
 func (c *client) TTL(ctx context.Context, key string) DurationCmd {
-	ctx = c.handler.before(ctx, CommandTTL)
+	ctx = c.handler.beforeWithKeys(ctx, CommandTTL, func() []string { return []string{key} })
 	var r DurationCmd
 	if c.ttl > 0 {
 		r = newDurationCmd(c.Do(ctx, c.builder.TTLCompleted(key)), time.Second)
@@ -744,7 +744,7 @@ func (c *client) TTL(ctx context.Context, key string) DurationCmd {
 }
 
 func (c *client) Type(ctx context.Context, key string) StatusCmd {
-	ctx = c.handler.before(ctx, CommandType)
+	ctx = c.handler.beforeWithKeys(ctx, CommandType, func() []string { return []string{key} })
 	var r StatusCmd
 	if c.ttl > 0 {
 		r = newStatusCmd(c.Do(ctx, c.builder.TypeCompleted(key)))
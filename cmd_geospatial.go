@@ -155,14 +155,14 @@ type GeospatialCacheCmdable interface {
 }
 
 func (c *client) GeoAdd(ctx context.Context, key string, geoLocation ...GeoLocation) IntCmd {
-	ctx = c.handler.before(ctx, CommandGeoAdd)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoAdd, func() []string { return []string{key} })
 	r := c.adapter.GeoAdd(ctx, key, geoLocation...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GeoDist(ctx context.Context, key string, member1, member2, unit string) FloatCmd {
-	ctx = c.handler.before(ctx, CommandGeoDist)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoDist, func() []string { return []string{key} })
 	var r FloatCmd
 	if c.ttl > 0 {
 		r = newFloatCmd(c.Do(ctx, c.builder.GeoDistCompleted(key, member1, member2, unit)))
@@ -174,14 +174,14 @@ func (c *client) GeoDist(ctx context.Context, key string, member1, member2, unit
 }
 
 func (c *client) GeoHash(ctx context.Context, key string, members ...string) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandGeoHash)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoHash, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.GeoHashCompleted(key, members...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GeoPos(ctx context.Context, key string, members ...string) GeoPosCmd {
-	ctx = c.handler.before(ctx, CommandGeoPos)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoPos, func() []string { return []string{key} })
 	var r GeoPosCmd
 	if c.ttl > 0 {
 		r = newGeoPosCmd(c.Do(ctx, c.builder.GeoPosCompleted(key, members...)))
@@ -193,7 +193,7 @@ func (c *client) GeoPos(ctx context.Context, key string, members ...string) GeoP
 }
 
 func (c *client) GeoRadius(ctx context.Context, key string, longitude, latitude float64, query GeoRadiusQuery) GeoLocationCmd {
-	ctx = c.handler.before(ctx, CommandGeoRadiusRO)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoRadiusRO, func() []string { return []string{key} })
 	var r GeoLocationCmd
 	if c.ttl > 0 {
 		r = newGeoLocationCmd(c.Do(ctx, c.builder.GeoRadiusCompleted(key, longitude, latitude, query)))
@@ -222,7 +222,7 @@ func (c *client) GeoRadiusStore(ctx context.Context, key string, longitude, lati
 }
 
 func (c *client) GeoRadiusByMember(ctx context.Context, key, member string, query GeoRadiusQuery) GeoLocationCmd {
-	ctx = c.handler.before(ctx, CommandGeoRadiusByMemberRO)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoRadiusByMemberRO, func() []string { return []string{key} })
 	var r GeoLocationCmd
 	if c.ttl > 0 {
 		r = newGeoLocationCmd(c.Do(ctx, c.builder.GeoRadiusByMemberCompleted(key, member, query)))
@@ -234,28 +234,28 @@ func (c *client) GeoRadiusByMember(ctx context.Context, key, member string, quer
 }
 
 func (c *client) GeoRadiusByMemberStore(ctx context.Context, key, member string, query GeoRadiusQuery) IntCmd {
-	ctx = c.handler.before(ctx, CommandGeoRadiusByMemberStore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoRadiusByMemberStore, func() []string { return []string{key} })
 	r := c.adapter.GeoRadiusByMemberStore(ctx, key, member, query)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GeoSearch(ctx context.Context, key string, q GeoSearchQuery) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandGeoSearch)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoSearch, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.GeoSearchCompleted(key, q)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GeoSearchLocation(ctx context.Context, key string, q GeoSearchLocationQuery) GeoLocationCmd {
-	ctx = c.handler.before(ctx, CommandGeoSearchLocation)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoSearchLocation, func() []string { return []string{key} })
 	r := c.adapter.GeoSearchLocation(ctx, key, q)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GeoSearchStore(ctx context.Context, key, store string, q GeoSearchStoreQuery) IntCmd {
-	ctx = c.handler.before(ctx, CommandGeoSearchStore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGeoSearchStore, func() []string { return []string{key} })
 	r := c.adapter.GeoSearchStore(ctx, key, store, q)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -334,9 +334,9 @@ type HashCacheCmdable interface {
 
 func (c *client) HDel(ctx context.Context, key string, fields ...string) IntCmd {
 	if len(fields) > 1 {
-		ctx = c.handler.before(ctx, CommandHMDel)
+		ctx = c.handler.beforeWithKeys(ctx, CommandHMDel, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandHDel)
+		ctx = c.handler.beforeWithKeys(ctx, CommandHDel, func() []string { return []string{key} })
 	}
 	r := c.adapter.HDel(ctx, key, fields...)
 	c.handler.after(ctx, r.Err())
@@ -344,7 +344,7 @@ func (c *client) HDel(ctx context.Context, key string, fields ...string) IntCmd
 }
 
 func (c *client) HExists(ctx context.Context, key, field string) BoolCmd {
-	ctx = c.handler.before(ctx, CommandHExists)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExists, func() []string { return []string{key} })
 	var r BoolCmd
 	if c.ttl > 0 {
 		r = newBoolCmd(c.Do(ctx, c.builder.HExistsCompleted(key, field)))
@@ -356,91 +356,91 @@ func (c *client) HExists(ctx context.Context, key, field string) BoolCmd {
 }
 
 func (c *client) HExpire(ctx context.Context, key string, seconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpire)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpire, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireCompleted(key, seconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireNX(ctx context.Context, key string, seconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireNX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireNXCompleted(key, seconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireXX(ctx context.Context, key string, seconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireXX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireXXCompleted(key, seconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireGT(ctx context.Context, key string, seconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireGT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireGTCompleted(key, seconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireLT(ctx context.Context, key string, seconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireLT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireLTCompleted(key, seconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireAt)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireAt, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireAtCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireAtNX(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireAtNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireAtNX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireAtNXCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireAtXX(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireAtXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireAtXX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireAtXXCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireAtGT(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireAtGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireAtGT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireAtGTCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireAtLT(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireAtLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireAtLT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HExpireAtLTCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HExpireTime(ctx context.Context, key string, fields ...string) DurationSliceCmd {
-	ctx = c.handler.before(ctx, CommandHExpireTime)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHExpireTime, func() []string { return []string{key} })
 	r := newDurationSliceCmd(c.Do(ctx, c.builder.HExpireTimeCompleted(key, fields...)), time.Second)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HGet(ctx context.Context, key, field string) StringCmd {
-	ctx = c.handler.before(ctx, CommandHGet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHGet, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.HGetCompleted(key, field)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HGetAll(ctx context.Context, key string) StringStringMapCmd {
-	ctx = c.handler.before(ctx, CommandHGetAll)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHGetAll, func() []string { return []string{key} })
 	var r StringStringMapCmd
 	if c.ttl > 0 {
 		r = newStringStringMapCmd(c.Do(ctx, c.builder.HGetAllCompleted(key)))
@@ -452,28 +452,28 @@ func (c *client) HGetAll(ctx context.Context, key string) StringStringMapCmd {
 }
 
 func (c *client) HIncrBy(ctx context.Context, key, field string, incr int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandHIncrBy)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHIncrBy, func() []string { return []string{key} })
 	r := c.adapter.HIncrBy(ctx, key, field, incr)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HIncrByFloat(ctx context.Context, key, field string, incr float64) FloatCmd {
-	ctx = c.handler.before(ctx, CommandHIncrByFloat)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHIncrByFloat, func() []string { return []string{key} })
 	r := c.adapter.HIncrByFloat(ctx, key, field, incr)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HKeys(ctx context.Context, key string) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandHKeys)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHKeys, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.HKeysCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HLen(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandHLen)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHLen, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.HLenCompleted(key)))
@@ -485,7 +485,7 @@ func (c *client) HLen(ctx context.Context, key string) IntCmd {
 }
 
 func (c *client) HMGet(ctx context.Context, key string, fields ...string) SliceCmd {
-	ctx = c.handler.before(ctx, CommandHMGet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHMGet, func() []string { return []string{key} })
 	var r SliceCmd
 	if c.ttl > 0 {
 		r = newSliceCmd(c.Do(ctx, c.builder.HMGetCompleted(key, fields...)), false, fields...)
@@ -497,126 +497,126 @@ func (c *client) HMGet(ctx context.Context, key string, fields ...string) SliceC
 }
 
 func (c *client) HMSet(ctx context.Context, key string, values ...any) BoolCmd {
-	ctx = c.handler.before(ctx, CommandHMSet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHMSet, func() []string { return []string{key} })
 	r := c.adapter.HMSet(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPersist(ctx context.Context, key string, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPersist)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPersist, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPersistCompleted(key, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpire(ctx context.Context, key string, milliseconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpire)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpire, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireCompleted(key, milliseconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireNX(ctx context.Context, key string, milliseconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireNX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireNXCompleted(key, milliseconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireXX(ctx context.Context, key string, milliseconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireXX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireXXCompleted(key, milliseconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireGT(ctx context.Context, key string, milliseconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireGT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireGTCompleted(key, milliseconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireLT(ctx context.Context, key string, milliseconds time.Duration, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireLT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireLTCompleted(key, milliseconds, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireAt)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireAt, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireAtCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireAtNX(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireAtNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireAtNX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireAtNXCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireAtXX(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireAtXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireAtXX, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireAtXXCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireAtGT(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireAtGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireAtGT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireAtGTCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireAtLT(ctx context.Context, key string, tm time.Time, fields ...string) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireAtLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireAtLT, func() []string { return []string{key} })
 	r := newIntSliceCmd(c.Do(ctx, c.builder.HPExpireAtLTCompleted(key, tm, fields...)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPExpireTime(ctx context.Context, key string, fields ...string) DurationSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPExpireTime)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPExpireTime, func() []string { return []string{key} })
 	r := newDurationSliceCmd(c.Do(ctx, c.builder.HPExpireTimeCompleted(key, fields...)), time.Millisecond)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HTTL(ctx context.Context, key string, fields ...string) DurationSliceCmd {
-	ctx = c.handler.before(ctx, CommandHTTL)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHTTL, func() []string { return []string{key} })
 	r := newDurationSliceCmd(c.Do(ctx, c.builder.HTTLCompleted(key, fields...)), time.Second)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HPTTL(ctx context.Context, key string, fields ...string) DurationSliceCmd {
-	ctx = c.handler.before(ctx, CommandHPTTL)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHPTTL, func() []string { return []string{key} })
 	r := newDurationSliceCmd(c.Do(ctx, c.builder.HPTTLCompleted(key, fields...)), time.Millisecond)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HRandField(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandHRandField)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHRandField, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.HRandFieldCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HRandFieldWithValues(ctx context.Context, key string, count int64) KeyValueSliceCmd {
-	ctx = c.handler.before(ctx, CommandHRandFieldWithValues)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHRandFieldWithValues, func() []string { return []string{key} })
 	r := c.adapter.HRandFieldWithValues(ctx, key, count)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) ScanCmd {
-	ctx = c.handler.before(ctx, CommandHScan)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHScan, func() []string { return []string{key} })
 	r := c.adapter.HScan(ctx, key, cursor, match, count)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -624,9 +624,9 @@ func (c *client) HScan(ctx context.Context, key string, cursor uint64, match str
 
 func (c *client) HSet(ctx context.Context, key string, values ...any) IntCmd {
 	if len(values) > 2 {
-		ctx = c.handler.before(ctx, CommandHMSetX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandHMSetX, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandHSet)
+		ctx = c.handler.beforeWithKeys(ctx, CommandHSet, func() []string { return []string{key} })
 	}
 	r := c.adapter.HSet(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
@@ -634,21 +634,21 @@ func (c *client) HSet(ctx context.Context, key string, values ...any) IntCmd {
 }
 
 func (c *client) HSetNX(ctx context.Context, key, field string, value any) BoolCmd {
-	ctx = c.handler.before(ctx, CommandHSetNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHSetNX, func() []string { return []string{key} })
 	r := c.adapter.HSetNX(ctx, key, field, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HVals(ctx context.Context, key string) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandHVals)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHVals, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.HValsCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) HStrLen(ctx context.Context, key, field string) IntCmd {
-	ctx = c.handler.before(ctx, CommandHStrLen)
+	ctx = c.handler.beforeWithKeys(ctx, CommandHStrLen, func() []string { return []string{key} })
 	var r IntCmd
 	r = newIntCmd(c.Do(ctx, c.builder.HStrLenCompleted(key, field)))
 	c.handler.after(ctx, r.Err())
@@ -41,7 +41,7 @@ type HyperLogReader interface {
 }
 
 func (c *client) PFAdd(ctx context.Context, key string, els ...any) IntCmd {
-	ctx = c.handler.before(ctx, CommandPFAdd)
+	ctx = c.handler.beforeWithKeys(ctx, CommandPFAdd, func() []string { return []string{key} })
 	r := c.adapter.PFAdd(ctx, key, els...)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -347,7 +347,7 @@ func (c *client) BRPopLPush(ctx context.Context, source, destination string, tim
 }
 
 func (c *client) LIndex(ctx context.Context, key string, index int64) StringCmd {
-	ctx = c.handler.before(ctx, CommandLIndex)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLIndex, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.LIndexCompleted(key, index)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -356,9 +356,9 @@ func (c *client) LIndex(ctx context.Context, key string, index int64) StringCmd
 func (c *client) LInsert(ctx context.Context, key, op string, pivot, value any) IntCmd {
 	switch strings.ToUpper(op) {
 	case BEFORE:
-		ctx = c.handler.before(ctx, CommandLInsertBefore)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLInsertBefore, func() []string { return []string{key} })
 	case AFTER:
-		ctx = c.handler.before(ctx, CommandLInsertAfter)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLInsertAfter, func() []string { return []string{key} })
 	default:
 		panic(fmt.Sprintf("Invalid op argument value: %s", op))
 	}
@@ -368,21 +368,21 @@ func (c *client) LInsert(ctx context.Context, key, op string, pivot, value any)
 }
 
 func (c *client) LInsertBefore(ctx context.Context, key string, pivot, value any) IntCmd {
-	ctx = c.handler.before(ctx, CommandLInsertBefore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLInsertBefore, func() []string { return []string{key} })
 	r := c.adapter.LInsertBefore(ctx, key, pivot, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LInsertAfter(ctx context.Context, key string, pivot, value any) IntCmd {
-	ctx = c.handler.before(ctx, CommandLInsertAfter)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLInsertAfter, func() []string { return []string{key} })
 	r := c.adapter.LInsertAfter(ctx, key, pivot, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LLen(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandLLen)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLLen, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.LLenCompleted(key)))
@@ -401,7 +401,7 @@ func (c *client) LMove(ctx context.Context, source, destination, srcpos, destpos
 }
 
 func (c *client) LPop(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandLPop)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLPop, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.LPopCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -415,14 +415,14 @@ func (c *client) LMPop(ctx context.Context, direction string, count int64, keys
 }
 
 func (c *client) LPopCount(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandLPopCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLPopCount, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.LPopCountCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LPos(ctx context.Context, key string, value string, args LPosArgs) IntCmd {
-	ctx = c.handler.before(ctx, CommandLPos)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLPos, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.LPosCompleted(key, value, args)))
@@ -434,7 +434,7 @@ func (c *client) LPos(ctx context.Context, key string, value string, args LPosAr
 }
 
 func (c *client) LPosCount(ctx context.Context, key string, value string, count int64, args LPosArgs) IntSliceCmd {
-	ctx = c.handler.before(ctx, CommandLPosCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLPosCount, func() []string { return []string{key} })
 	r := c.adapter.LPosCount(ctx, key, value, count, args)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -442,9 +442,9 @@ func (c *client) LPosCount(ctx context.Context, key string, value string, count
 
 func (c *client) LPush(ctx context.Context, key string, values ...any) IntCmd {
 	if len(values) > 1 {
-		ctx = c.handler.before(ctx, CommandLMPush)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLMPush, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandLPush)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLPush, func() []string { return []string{key} })
 	}
 	r := c.adapter.LPush(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
@@ -453,9 +453,9 @@ func (c *client) LPush(ctx context.Context, key string, values ...any) IntCmd {
 
 func (c *client) LPushX(ctx context.Context, key string, values ...any) IntCmd {
 	if len(values) > 1 {
-		ctx = c.handler.before(ctx, CommandLMPushX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLMPushX, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandLPushX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandLPushX, func() []string { return []string{key} })
 	}
 	r := c.adapter.LPushX(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
@@ -463,42 +463,42 @@ func (c *client) LPushX(ctx context.Context, key string, values ...any) IntCmd {
 }
 
 func (c *client) LRange(ctx context.Context, key string, start, stop int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandLRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLRange, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.LRangeCompleted(key, start, stop)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LRem(ctx context.Context, key string, count int64, value any) IntCmd {
-	ctx = c.handler.before(ctx, CommandLRem)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLRem, func() []string { return []string{key} })
 	r := c.adapter.LRem(ctx, key, count, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LSet(ctx context.Context, key string, index int64, value any) StatusCmd {
-	ctx = c.handler.before(ctx, CommandLSet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLSet, func() []string { return []string{key} })
 	r := c.adapter.LSet(ctx, key, index, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) LTrim(ctx context.Context, key string, start, stop int64) StatusCmd {
-	ctx = c.handler.before(ctx, CommandLTrim)
+	ctx = c.handler.beforeWithKeys(ctx, CommandLTrim, func() []string { return []string{key} })
 	r := c.adapter.LTrim(ctx, key, start, stop)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) RPop(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandRPop)
+	ctx = c.handler.beforeWithKeys(ctx, CommandRPop, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.RPopCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) RPopCount(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandRPopCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandRPopCount, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.RPopCountCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -513,9 +513,9 @@ func (c *client) RPopLPush(ctx context.Context, source, destination string) Stri
 
 func (c *client) RPush(ctx context.Context, key string, values ...any) IntCmd {
 	if len(values) > 1 {
-		ctx = c.handler.before(ctx, CommandRMPush)
+		ctx = c.handler.beforeWithKeys(ctx, CommandRMPush, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandRPush)
+		ctx = c.handler.beforeWithKeys(ctx, CommandRPush, func() []string { return []string{key} })
 	}
 	r := c.adapter.RPush(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
@@ -524,9 +524,9 @@ func (c *client) RPush(ctx context.Context, key string, values ...any) IntCmd {
 
 func (c *client) RPushX(ctx context.Context, key string, values ...any) IntCmd {
 	if len(values) > 1 {
-		ctx = c.handler.before(ctx, CommandRMPushX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandRMPushX, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandRPushX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandRPushX, func() []string { return []string{key} })
 	}
 	r := c.adapter.RPushX(ctx, key, values...)
 	c.handler.after(ctx, r.Err())
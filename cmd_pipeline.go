@@ -2,11 +2,42 @@ package redisson
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
+	"unsafe"
+
+	"github.com/redis/rueidis"
 )
 
+// ErrTxFailed 事务执行失败，WATCH 的 key 在 EXEC 之前被修改
+var ErrTxFailed = errors.New("redis: transaction failed")
+
 type PipelineCmdable interface {
 	Pipeline() Pipeliner
+
+	// TxPipeline 事务管道
+	// Exec 或 ExecCmds 时，会在专用连接上以 MULTI/EXEC 包裹所有命令执行
+	// 在 Development 的情况下，集群模式会校验所有命令是否在同一槽位
+	TxPipeline() Pipeliner
+
+	// Watch 基于 WATCH 的乐观锁事务
+	// 在专用连接上 WATCH keys 后调用 fn，fn 中通过 Tx 读取数据，并通过 Tx.TxPipeline 提交事务
+	// 如果 WATCH 的 key 在 EXEC 之前被修改，Exec 或 ExecCmds 返回 ErrTxFailed
+	Watch(ctx context.Context, fn func(Tx) error, keys ...string) error
+}
+
+// Tx 持有 WATCH 的专用连接
+type Tx interface {
+	// Do 在专用连接上执行命令
+	Do(ctx context.Context, completed Completed) RedisResult
+	// Pipeline 在专用连接上以管道执行命令，一般用于读取 WATCH 的 key
+	Pipeline() Pipeliner
+	// TxPipeline 在专用连接上以 MULTI/EXEC 执行命令
+	// 如果 WATCH 的 key 在 EXEC 之前被修改，Exec 或 ExecCmds 返回 ErrTxFailed
+	TxPipeline() Pipeliner
+	// Unwatch 取消所有 WATCH 的 key
+	Unwatch(ctx context.Context) error
 }
 
 type Pipeliner interface {
@@ -35,17 +66,91 @@ func (pipelineCommand) WarningOnce() bool      { return false }
 func (pipelineCommand) Instead() string        { return "" }
 func (pipelineCommand) ETC() string            { return "" }
 
-var pipelineCmd = &pipelineCommand{}
+type txPipelineCommand struct{ pipelineCommand }
+
+func (txPipelineCommand) String() string         { return "MULTI" }
+func (txPipelineCommand) RequireVersion() string { return "1.2.0" }
+
+type watchCommand struct{ pipelineCommand }
+
+func (watchCommand) String() string         { return "WATCH" }
+func (watchCommand) Class() string          { return "Transactions" }
+func (watchCommand) RequireVersion() string { return "2.2.0" }
+
+type unwatchCommand struct{ watchCommand }
+
+func (unwatchCommand) String() string { return "UNWATCH" }
+
+// txCommand Tx.Do 执行的命令，以命令名称统计
+type txCommand struct {
+	pipelineCommand
+	name string
+}
+
+func (c txCommand) String() string { return c.name }
+func (txCommand) Class() string    { return "Transactions" }
+
+var (
+	pipelineCmd   = &pipelineCommand{}
+	txPipelineCmd = &txPipelineCommand{}
+	watchCmd      = &watchCommand{}
+	unwatchCmd    = &unwatchCommand{}
+)
 
 type pipeline struct {
 	client   *client
+	dc       rueidis.CoreClient
+	tx       bool
 	commands []Completed
 	rets     []BaseCmd
 
 	mx sync.RWMutex
 }
 
-func (c *client) Pipeline() Pipeliner { return &pipeline{client: c} }
+func (c *client) Pipeline() Pipeliner   { return &pipeline{client: c} }
+func (c *client) TxPipeline() Pipeliner { return &pipeline{client: c, tx: true} }
+
+func (c *client) Watch(ctx context.Context, fn func(Tx) error, keys ...string) error {
+	return c.cmd.Dedicated(func(dc rueidis.DedicatedClient) error {
+		if len(keys) > 0 {
+			ctx0 := c.handler.beforeWithKeys(ctx, watchCmd, func() []string { return keys })
+			err := dc.Do(ctx0, dc.B().Watch().Key(keys...).Build()).Error()
+			c.handler.after(ctx0, err)
+			if err != nil {
+				return err
+			}
+		}
+		t := &tx{client: c, dc: dc, keys: keys}
+		// 连接归还连接池前，需要取消 WATCH，否则会影响后续使用该连接的事务
+		defer func() { _ = t.Unwatch(context.Background()) }()
+		return fn(t)
+	})
+}
+
+type tx struct {
+	client *client
+	dc     rueidis.DedicatedClient
+	keys   []string
+}
+
+// Do 集群模式下专用连接只能访问 WATCH 的 key 所在的槽位，跨槽位的命令由 rueidis 拒绝
+func (t *tx) Do(ctx context.Context, completed Completed) RedisResult {
+	command := txCommand{name: strings.ToUpper(completed.Commands()[0])}
+	ctx = t.client.handler.beforeWithKeys(ctx, command, func() []string { return t.keys })
+	resp := t.dc.Do(ctx, completed)
+	t.client.handler.after(ctx, resp.Error())
+	return resp
+}
+func (t *tx) Pipeline() Pipeliner { return &pipeline{client: t.client, dc: t.dc} }
+func (t *tx) TxPipeline() Pipeliner {
+	return &pipeline{client: t.client, dc: t.dc, tx: true}
+}
+func (t *tx) Unwatch(ctx context.Context) error {
+	ctx = t.client.handler.before(ctx, unwatchCmd)
+	err := t.dc.Do(ctx, t.dc.B().Unwatch().Build()).Error()
+	t.client.handler.after(ctx, err)
+	return err
+}
 
 func (p *pipeline) builder() builder { return p.client.builder }
 func (p *pipeline) cmd(cs Completed, ret BaseCmd) {
@@ -56,9 +161,7 @@ func (p *pipeline) cmd(cs Completed, ret BaseCmd) {
 	return
 }
 
-func (p *pipeline) exec(ctx context.Context, f func([]Completed, []BaseCmd) error) {
-	ctx = p.client.handler.before(ctx, pipelineCmd)
-
+func (p *pipeline) exec(ctx context.Context, f func([]RedisResult, []BaseCmd) error) (err error) {
 	var cmds []Completed
 	var rets []BaseCmd
 	p.mx.RLock()
@@ -66,62 +169,126 @@ func (p *pipeline) exec(ctx context.Context, f func([]Completed, []BaseCmd) erro
 	rets = p.rets
 	p.mx.RUnlock()
 
-	var firstError error
+	if p.tx {
+		ctx = p.client.handler.beforePipeline(ctx, txPipelineCmd, cmds, true)
+	} else {
+		ctx = p.client.handler.beforePipeline(ctx, pipelineCmd, cmds, false)
+	}
 	defer func() {
-		p.client.handler.after(ctx, firstError)
+		p.client.handler.after(ctx, err)
 	}()
 
 	if len(cmds) == 0 {
 		return
 	}
-	firstError = f(cmds, rets)
+	var resps []RedisResult
+	if resps, err = p.doMulti(ctx, cmds); resps == nil {
+		return
+	}
+	if err0 := f(resps, rets); err == nil {
+		err = err0
+	}
 	return
 }
 
-func (p *pipeline) Exec(ctx context.Context) (result []any, err error) {
-	p.exec(ctx, func(cmds []Completed, _ []BaseCmd) error {
-		result = make([]any, len(cmds))
+func (p *pipeline) core() rueidis.CoreClient {
+	if p.dc != nil {
+		return p.dc
+	}
+	return p.client.cmd
+}
+
+func (p *pipeline) doMulti(ctx context.Context, cmds []Completed) ([]RedisResult, error) {
+	if !p.tx {
 		if len(cmds) == 1 {
-			result[0], err = p.client.cmd.Do(ctx, cmds[0]).ToAny()
-			if err != nil && result[0] == nil {
-				result[0] = err
+			return []RedisResult{p.core().Do(ctx, cmds[0])}, nil
+		}
+		return p.core().DoMulti(ctx, cmds...), nil
+	}
+	multi := make([]Completed, 0, len(cmds)+2)
+	multi = append(multi, p.client.cmd.B().Multi().Build())
+	multi = append(multi, cmds...)
+	multi = append(multi, p.client.cmd.B().Exec().Build())
+	if p.dc != nil {
+		return unpackExec(p.dc.DoMulti(ctx, multi...), len(cmds))
+	}
+	var resps []RedisResult
+	err := p.client.cmd.Dedicated(func(dc rueidis.DedicatedClient) error {
+		resps = dc.DoMulti(ctx, multi...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unpackExec(resps, len(cmds))
+}
+
+// unpackExec 将 MULTI/EXEC 的结果展开为每条命令的结果
+func unpackExec(resps []RedisResult, n int) ([]RedisResult, error) {
+	rets := make([]RedisResult, n)
+	values, err := resps[len(resps)-1].ToArray()
+	if err != nil {
+		if IsNil(err) {
+			err = ErrTxFailed
+		}
+		for i := range rets {
+			if err0 := resps[i+1].Error(); err0 != nil {
+				rets[i] = newRedisResult(rueidis.RedisMessage{}, err0)
+			} else {
+				rets[i] = newRedisResult(rueidis.RedisMessage{}, err)
+			}
+		}
+		return rets, err
+	}
+	for i, v := range values {
+		rets[i] = newRedisResult(v, resps[i+1].NonRedisError())
+	}
+	return rets, nil
+}
+
+type proxyResult struct {
+	err error
+	val rueidis.RedisMessage
+}
+
+// newRedisResult rueidis 未导出 RedisResult 的构造函数，与 rueidiscompat 的实现方式一致
+func newRedisResult(val rueidis.RedisMessage, err error) RedisResult {
+	return *(*RedisResult)(unsafe.Pointer(&proxyResult{err: err, val: val}))
+}
+
+func (p *pipeline) Exec(ctx context.Context) (result []any, err error) {
+	err = p.exec(ctx, func(resps []RedisResult, _ []BaseCmd) error {
+		var firstError error
+		result = make([]any, len(resps))
+		for i, resp := range resps {
+			var err0 error
+			result[i], err0 = resp.ToAny()
+			if err0 == nil {
+				continue
+			}
+			if firstError == nil {
+				firstError = err0
 			}
-		} else {
-			for i, resp := range p.client.cmd.DoMulti(ctx, cmds...) {
-				var err0 error
-				result[i], err0 = resp.ToAny()
-				if err0 == nil {
-					continue
-				}
-				if err == nil {
-					err = err0
-				}
-				if result[i] == nil {
-					result[i] = err0
-				}
+			if result[i] == nil {
+				result[i] = err0
 			}
 		}
-		return err
+		return firstError
 	})
 	return
 }
 
 func (p *pipeline) ExecCmds(ctx context.Context) (rets []BaseCmd, err error) {
-	p.exec(ctx, func(cmds []Completed, in []BaseCmd) error {
+	err = p.exec(ctx, func(resps []RedisResult, in []BaseCmd) error {
+		var firstError error
 		rets = in
-		if len(cmds) == 1 {
-			resp := p.client.cmd.Do(ctx, cmds[0])
-			err = resp.NonRedisError()
-			rets[0].(fromRedisResult).from(resp)
-		} else {
-			for i, resp := range p.client.cmd.DoMulti(ctx, cmds...) {
-				if err0 := resp.NonRedisError(); err0 != nil && err == nil {
-					err = err0
-				}
-				rets[i].(fromRedisResult).from(resp)
+		for i, resp := range resps {
+			if err0 := resp.NonRedisError(); err0 != nil && firstError == nil {
+				firstError = err0
 			}
+			rets[i].(fromRedisResult).from(resp)
 		}
-		return err
+		return firstError
 	})
 	return
 }
@@ -43,9 +43,71 @@ func testPipeline(ctx context.Context, c Cmdable) []string {
 	return []string{key1, key2}
 }
 
+type _txPipeline string
+
+func (_txPipeline) String() string { return "TxPipeline" }
+
+func testTxPipeline(ctx context.Context, c Cmdable) []string {
+	var key1, key2, value1 = "key1:{1}", "key2:{1}", "value1"
+	pip := c.TxPipeline()
+	CommandSet.P(pip).Cmd(key1, value1, 0)
+	CommandIncr.P(pip).Cmd(key2)
+	CommandGet.P(pip).Cmd(key1)
+
+	cmds, err := pip.ExecCmds(ctx)
+	So(err, ShouldBeNil)
+	So(len(cmds), ShouldEqual, 3)
+	So(CommandSet.PR(cmds[0]).Val(), ShouldEqual, OK)
+	So(CommandIncr.PR(cmds[1]).Val(), ShouldEqual, 1)
+	So(CommandGet.PR(cmds[2]).Val(), ShouldEqual, value1)
+
+	return []string{key1, key2}
+}
+
+type _watch string
+
+func (_watch) String() string { return "Watch" }
+
+func testWatch(ctx context.Context, c Cmdable) []string {
+	var key = "key:{1}"
+	So(c.Set(ctx, key, 1, 0).Err(), ShouldBeNil)
+
+	var incr = func(modify bool) error {
+		return c.Watch(ctx, func(tx Tx) error {
+			rp := tx.Pipeline()
+			CommandGet.P(rp).Cmd(key)
+			cmds, err := rp.ExecCmds(ctx)
+			if err != nil {
+				return err
+			}
+			n, err := CommandGet.PR(cmds[0]).Int64()
+			if err != nil {
+				return err
+			}
+			if modify {
+				So(c.Set(ctx, key, 100, 0).Err(), ShouldBeNil)
+			}
+			pip := tx.TxPipeline()
+			CommandSet.P(pip).Cmd(key, n+1, 0)
+			_, err = pip.ExecCmds(ctx)
+			return err
+		}, key)
+	}
+
+	So(incr(false), ShouldBeNil)
+	So(c.Get(ctx, key).Val(), ShouldEqual, "2")
+
+	So(incr(true), ShouldEqual, ErrTxFailed)
+	So(c.Get(ctx, key).Val(), ShouldEqual, "100")
+
+	return []string{key}
+}
+
 func pipelineTestUnits() []TestUnit {
 	return []TestUnit{
 		{new(_pipeline), testPipeline},
+		{new(_txPipeline), testTxPipeline},
+		{new(_watch), testWatch},
 	}
 }
 
@@ -2,6 +2,9 @@ package redisson
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/redis/rueidis"
 )
@@ -43,10 +46,40 @@ type PubSub interface {
 	//		Instead, for each pattern, one message with the first element being the string punsubscribe is pushed as a confirmation that the command succeeded.
 	PUnsubscribe(ctx context.Context, patterns ...string) error
 
+	// SSubscribe
+	// Available since: 7.0.0
+	// Time complexity: O(N) where N is the number of shard channels to subscribe to.
+	// ACL categories: @pubsub @slow
+	// RESP2 / RESP3 Reply:
+	// 	- When successful, this command doesn't return anything.
+	//		Instead, for each shard channel, one message with the first element being the string ssubscribe is pushed as a confirmation that the command succeeded.
+	// Shard channels are grouped by node, and each node holds a dedicated connection, only one connection is held in non-cluster mode.
+	// Shard channels are resubscribed on the new node after the slot is migrated.
+	SSubscribe(ctx context.Context, channels ...string) error
+
+	// SUnsubscribe
+	// Available since: 7.0.0
+	// Time complexity: O(N) where N is the number of shard channels to unsubscribe.
+	// ACL categories: @pubsub @slow
+	// RESP2 / RESP3 Reply:
+	// 	- When successful, this command doesn't return anything.
+	//		Instead, for each shard channel, one message with the first element being the string sunsubscribe is pushed as a confirmation that the command succeeded.
+	SUnsubscribe(ctx context.Context, channels ...string) error
+
 	// Channel
-	// Receive Message by chan
+	// Receive Message by chan, the chan is closed when the subscription is done
 	Channel() <-chan Message
 
+	// Err
+	// Receive asynchronous errors by chan, such as disconnections and resubscription failures.
+	// Errors are dropped if they are not received in time
+	Err() <-chan error
+
+	// Done
+	// The chan is closed when the subscription is done, e.g. Close is called, ctx is done, the client is closed,
+	// or resubscription fails more than MaxRetries times
+	Done() <-chan struct{}
+
 	// Close
 	// Release the hold connection
 	Close() error
@@ -104,6 +137,11 @@ type PubSubCmdable interface {
 	//		Instead, for each channel, one message with the first element being the string subscribe is pushed as a confirmation that the command succeeded.
 	Subscribe(ctx context.Context, channels ...string) PubSub
 
+	// NewPubSub
+	// Create a PubSub without any subscription. It holds a dedicated connection, and resubscribes all channels and patterns
+	// with RetryPolicy after the connection is broken.
+	NewPubSub(ctx context.Context, opts ...PubSubOption) PubSub
+
 	// PubSubShardChannels
 	// Available since: 7.0.0
 	// Time complexity: O(N) where N is the number of active shard channels, and assuming constant time pattern matching (relatively short shard channels).
@@ -172,86 +210,800 @@ func (c *client) PubSubShardNumSub(ctx context.Context, channels ...string) Stri
 
 func (c *client) Subscribe(ctx context.Context, channels ...string) PubSub {
 	ctx = c.handler.before(ctx, CommandSubscribe)
-	r := newPubSub(ctx, c, c.handler, channels...)
-	c.handler.after(ctx, nil)
+	r := newPubSub(ctx, c, c.handler)
+	var err error
+	if len(channels) > 0 {
+		if err = r.Subscribe(ctx, channels...); err != nil {
+			r.report(err)
+		}
+	}
+	c.handler.after(ctx, err)
 	return r
 }
 
+func (c *client) NewPubSub(ctx context.Context, opts ...PubSubOption) PubSub {
+	return newPubSub(ctx, c, c.handler, opts...)
+}
+
 func (c *client) Receive(ctx context.Context, cb func(Message), channels ...string) error {
 	return c.cmd.Receive(ctx, c.cmd.B().Subscribe().Channel(channels...).Build(), func(msg rueidis.PubSubMessage) {
 		cb(msg)
 	})
 }
 
+// SReceive 订阅分片频道，集群模式下按照 slot 分组，每组单独订阅，任意一组结束时结束所有的订阅并返回
+func (c *client) SReceive(ctx context.Context, cb func(Message), channels ...string) error {
+	groups := make(map[uint16][]string)
+	for _, channel := range channels {
+		sl := slot(channel)
+		groups[sl] = append(groups[sl], channel)
+	}
+	if !c.isCluster || len(groups) <= 1 {
+		return c.sreceive(ctx, cb, channels)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, len(groups))
+	for _, names := range groups {
+		go func(names []string) {
+			errs <- c.sreceive(ctx, cb, names)
+		}(names)
+	}
+	err := <-errs
+	cancel()
+	for i := 1; i < len(groups); i++ {
+		<-errs
+	}
+	return err
+}
+
+func (c *client) sreceive(ctx context.Context, cb func(Message), channels []string) error {
+	return c.cmd.Receive(ctx, c.cmd.B().Ssubscribe().Channel(channels...).Build(), func(msg rueidis.PubSubMessage) {
+		cb(msg)
+	})
+}
+
 func (c *client) PReceive(ctx context.Context, cb func(Message), patterns ...string) error {
 	return c.cmd.Receive(ctx, c.cmd.B().Psubscribe().Pattern(patterns...).Build(), func(msg rueidis.PubSubMessage) {
 		cb(msg)
 	})
 }
 
+var (
+	ErrPubSubClosed       = errors.New("pubsub is closed")
+	ErrPubSubSlotMoved    = errors.New("pubsub shard channels are unsubscribed by server, the slot may be migrated")
+	ErrPubSubNodeNotFound = errors.New("pubsub shard node is not found")
+)
+
+// PubSubOverflowPolicy 消息缓冲区已满时的处理策略
+type PubSubOverflowPolicy int
+
+const (
+	// PubSubOverflowBlock 等待消费者读取，等待期间会阻塞该连接上的所有消息，可以通过 SendTimeout 限制等待时间
+	PubSubOverflowBlock PubSubOverflowPolicy = iota
+	// PubSubOverflowDropOldest 丢弃缓冲区中最早的消息
+	PubSubOverflowDropOldest
+	// PubSubOverflowDropNewest 丢弃新收到的消息
+	PubSubOverflowDropNewest
+	// PubSubOverflowCallback 将新收到的消息交给 OnOverflow 处理，未设置 OnOverflow 时丢弃
+	PubSubOverflowCallback
+)
+
+const (
+	pubSubStateConnected    = "connected"
+	pubSubStateReconnecting = "reconnecting"
+	pubSubResultSuccess     = "success"
+	pubSubResultFailure     = "failure"
+	pubSubDropOldest        = "oldest"
+	pubSubDropNewest        = "newest"
+	pubSubDropTimeout       = "timeout"
+	pubSubErrChanSize       = 8
+	// pubSubMinRetryInterval 重新订阅的最小间隔，避免 RetryPolicy 为 nil 或者退避时间过短时连续失败占满 CPU
+	pubSubMinRetryInterval = 50 * time.Millisecond
+)
+
+// pubSub 独占一个连接，连接断开后按照 RetryPolicy 重新订阅所有的频道以及模式
+// 分片频道按照节点分组，每个节点独占一个连接，非集群模式下只使用一个连接，连接断开或者 slot 迁移后重新订阅
 type pubSub struct {
 	client  *client
-	msgCh   chan Message
 	handler handler
+	spec    PubSubOptionsVisitor
+	msgCh   chan Message
+	errCh   chan error
+	done    chan struct{}
+
+	// mu 保护专用连接以及已订阅的频道、模式
+	mu       sync.Mutex
+	dc       rueidis.DedicatedClient
+	release  func()
+	channels map[string]struct{}
+	patterns map[string]struct{}
+	shards   map[string]*pubSubShard
+	// state 只在监控协程中修改
+	state string
+	// wg 等待分片连接的监控协程退出
+	wg sync.WaitGroup
+
+	// sendMu 保证关闭 msgCh 之后不再写入，关闭时先取消 ctx 结束等待中的写入，再归还连接，最后关闭 msgCh
+	sendMu sync.RWMutex
+	closed bool
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-func newPubSub(ctx context.Context, client *client, handler handler, channels ...string) PubSub {
-	// chan size todo, use goredis.ChannelOption?
-	p := &pubSub{client: client, msgCh: make(chan Message, 100), handler: handler}
-	p.ctx, p.cancel = context.WithCancel(ctx)
-	if len(channels) > 0 {
-		_ = p.Subscribe(ctx, channels...)
+func newPubSub(ctx context.Context, client *client, handler handler, opts ...PubSubOption) *pubSub {
+	spec := newPubSubOptions(opts...)
+	p := &pubSub{
+		client:   client,
+		handler:  handler,
+		spec:     spec,
+		msgCh:    make(chan Message, spec.GetChannelSize()),
+		errCh:    make(chan error, pubSubErrChanSize),
+		done:     make(chan struct{}),
+		channels: make(map[string]struct{}),
+		patterns: make(map[string]struct{}),
+		shards:   make(map[string]*pubSubShard),
 	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	p.mu.Lock()
+	hookErr := p.dedicate()
+	p.mu.Unlock()
+	p.setState(pubSubStateConnected)
+	go p.supervise(hookErr)
 	return p
 }
 
-func (p *pubSub) Close() error {
+// dedicate 获取专用连接并注册回调，返回的 chan 在连接断开时收到错误，调用方需持有 mu
+func (p *pubSub) dedicate() <-chan error {
+	p.dc, p.release = p.client.cmd.Dedicate()
+	return p.dc.SetPubSubHooks(rueidis.PubSubHooks{OnMessage: p.onMessage, OnSubscription: p.onSubscription})
+}
+
+// releaseLocked 归还专用连接，调用方需持有 mu
+func (p *pubSub) releaseLocked() {
+	if p.release != nil {
+		p.release()
+	}
+	p.dc, p.release = nil, nil
+}
+
+func (p *pubSub) onMessage(m Message) {
+	p.sendMu.RLock()
+	defer p.sendMu.RUnlock()
+	if p.closed {
+		return
+	}
+	select {
+	case p.msgCh <- m:
+		return
+	default:
+	}
+	switch p.spec.GetOverflowPolicy() {
+	case PubSubOverflowDropOldest:
+		// 先丢弃再写入，同时等待两者时可能连续丢弃多条消息
+		for cap(p.msgCh) > 0 {
+			select {
+			case <-p.msgCh:
+				p.handler.pubSubDropped(pubSubDropOldest)
+			default:
+			}
+			select {
+			case p.msgCh <- m:
+				return
+			default:
+			}
+		}
+		p.handler.pubSubDropped(pubSubDropNewest)
+	case PubSubOverflowDropNewest:
+		p.handler.pubSubDropped(pubSubDropNewest)
+	case PubSubOverflowCallback:
+		if cb := p.spec.GetOnOverflow(); cb != nil {
+			cb(m)
+		} else {
+			p.handler.pubSubDropped(pubSubDropNewest)
+		}
+	default:
+		var timeout <-chan time.Time
+		if d := p.spec.GetSendTimeout(); d > 0 {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case p.msgCh <- m:
+		case <-timeout:
+			p.handler.pubSubDropped(pubSubDropTimeout)
+		case <-p.ctx.Done():
+		}
+	}
+}
+
+func (p *pubSub) onSubscription(s Subscription) {
+	if cb := p.spec.GetOnSubscribed(); cb != nil && (s.Kind == "subscribe" || s.Kind == "psubscribe" || s.Kind == "ssubscribe") {
+		cb(s)
+	}
+}
+
+// report 发送异步错误，未及时读取时丢弃
+func (p *pubSub) report(err error) {
+	select {
+	case p.errCh <- err:
+	default:
+	}
+}
+
+func (p *pubSub) setState(state string) {
+	if p.state != "" {
+		p.handler.pubSubState(p.state, -1)
+	}
+	p.state = state
+	if state != "" {
+		p.handler.pubSubState(state, 1)
+	}
+}
+
+// supervise 监控连接，连接断开后重新订阅，直到订阅结束
+func (p *pubSub) supervise(hookErr <-chan error) {
+	defer p.stop()
+	for {
+		var err error
+		select {
+		case <-p.ctx.Done():
+			return
+		case err = <-hookErr:
+		}
+		if p.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = rueidis.ErrClosing
+		}
+		p.report(err)
+		if errors.Is(err, rueidis.ErrClosing) {
+			// 客户端已关闭
+			return
+		}
+		p.setState(pubSubStateReconnecting)
+		if cb := p.spec.GetOnDisconnected(); cb != nil {
+			cb(err)
+		}
+		p.mu.Lock()
+		p.releaseLocked()
+		p.mu.Unlock()
+		if hookErr = p.reconnect(p.resubscribe); hookErr == nil {
+			return
+		}
+		p.setState(pubSubStateConnected)
+	}
+}
+
+// reconnect 按照 RetryPolicy 重新订阅，放弃或者无需重新订阅时返回 nil
+func (p *pubSub) reconnect(resubscribe func() (<-chan error, error)) <-chan error {
+	for attempts := 1; ; attempts++ {
+		d := pubSubMinRetryInterval
+		if policy := p.spec.GetRetryPolicy(); policy != nil && policy.Backoff(attempts) > d {
+			d = policy.Backoff(attempts)
+		}
+		select {
+		case <-p.ctx.Done():
+			return nil
+		case <-time.After(d):
+		}
+		hookErr, err := resubscribe()
+		if hookErr == nil && err == nil {
+			return nil
+		}
+		if err == nil {
+			p.handler.pubSubReconnect(pubSubResultSuccess)
+			if cb := p.spec.GetOnResubscribed(); cb != nil {
+				cb(attempts)
+			}
+			return hookErr
+		}
+		p.handler.pubSubReconnect(pubSubResultFailure)
+		if p.ctx.Err() != nil {
+			return nil
+		}
+		p.report(err)
+		if maxRetries := p.spec.GetMaxRetries(); maxRetries > 0 && attempts >= maxRetries {
+			return nil
+		}
+	}
+}
+
+// resubscribe 获取新的专用连接，并重新订阅所有的频道以及模式
+func (p *pubSub) resubscribe() (<-chan error, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hookErr := p.dedicate()
+	var cmds rueidis.Commands
+	if len(p.channels) > 0 {
+		cmds = append(cmds, p.dc.B().Subscribe().Channel(setKeys(p.channels)...).Build())
+	}
+	if len(p.patterns) > 0 {
+		cmds = append(cmds, p.dc.B().Psubscribe().Pattern(setKeys(p.patterns)...).Build())
+	}
+	if len(cmds) == 0 {
+		// 没有订阅时，通过 PING 确认连接可用
+		cmds = append(cmds, p.dc.B().Ping().Build())
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, p.client.v.GetWriteTimeout())
+	defer cancel()
+	for _, resp := range p.dc.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			p.releaseLocked()
+			return nil, err
+		}
+	}
+	return hookErr, nil
+}
+
+// stop 结束订阅，归还专用连接并关闭 msgCh
+func (p *pubSub) stop() {
+	p.cancel()
+	p.mu.Lock()
+	p.releaseLocked()
+	for _, shard := range p.shards {
+		shard.releaseLocked()
+	}
+	p.mu.Unlock()
+	// 持有 mu 时 ctx 已取消，之后不会再创建分片连接的监控协程
+	p.wg.Wait()
+	p.setState("")
+	p.sendMu.Lock()
+	p.closed = true
 	close(p.msgCh)
+	p.sendMu.Unlock()
+	close(p.done)
+}
+
+func (p *pubSub) Close() error {
 	p.cancel()
+	<-p.done
 	return nil
 }
 
+// do 在专用连接上执行订阅或者取消订阅，并记录订阅的频道，用于重新订阅
+// 连接断开导致订阅失败时依然记录，由重新订阅处理
+func (p *pubSub) do(ctx context.Context, set map[string]struct{}, names []string, subscribe bool, build func(b Builder) Completed) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil {
+		return ErrPubSubClosed
+	}
+	var err error
+	if p.dc != nil {
+		err = p.dc.Do(ctx, build(p.dc.B())).Error()
+		if _, ok := rueidis.IsRedisErr(err); ok && subscribe {
+			return err
+		}
+	}
+	switch {
+	case subscribe:
+		for _, name := range names {
+			set[name] = struct{}{}
+		}
+	case len(names) == 0:
+		clear(set)
+	default:
+		for _, name := range names {
+			delete(set, name)
+		}
+	}
+	return err
+}
+
 func (p *pubSub) PSubscribe(ctx context.Context, patterns ...string) error {
 	ctx = p.handler.before(ctx, CommandPSubscribe)
-	var err error
-	go func() {
-		err = p.client.cmd.Receive(p.ctx, p.client.cmd.B().Psubscribe().Pattern(patterns...).Build(), func(m rueidis.PubSubMessage) {
-			p.msgCh <- m
-		})
-	}()
+	err := p.do(ctx, p.patterns, patterns, true, func(b Builder) Completed {
+		return b.Psubscribe().Pattern(patterns...).Build()
+	})
 	p.handler.after(ctx, err)
 	return err
 }
 
 func (p *pubSub) Subscribe(ctx context.Context, channels ...string) error {
 	ctx = p.handler.before(ctx, CommandSubscribe)
-	var err error
-	go func() {
-		err = p.client.cmd.Receive(p.ctx, p.client.cmd.B().Subscribe().Channel(channels...).Build(), func(m rueidis.PubSubMessage) {
-			p.msgCh <- m
-		})
-	}()
+	err := p.do(ctx, p.channels, channels, true, func(b Builder) Completed {
+		return b.Subscribe().Channel(channels...).Build()
+	})
 	p.handler.after(ctx, err)
 	return err
 }
 
 func (p *pubSub) Unsubscribe(ctx context.Context, channels ...string) error {
 	ctx = p.handler.before(ctx, CommandUnsubscribe)
-	err := p.client.cmd.Do(ctx, p.client.cmd.B().Unsubscribe().Channel(channels...).Build()).Error()
+	err := p.do(ctx, p.channels, channels, false, func(b Builder) Completed {
+		return b.Unsubscribe().Channel(channels...).Build()
+	})
 	p.handler.after(ctx, err)
 	return err
 }
 
 func (p *pubSub) PUnsubscribe(ctx context.Context, patterns ...string) error {
 	ctx = p.handler.before(ctx, CommandPUnsubscribe)
-	err := p.client.cmd.Do(ctx, p.client.cmd.B().Punsubscribe().Pattern(patterns...).Build()).Error()
+	err := p.do(ctx, p.patterns, patterns, false, func(b Builder) Completed {
+		return b.Punsubscribe().Pattern(patterns...).Build()
+	})
+	p.handler.after(ctx, err)
+	return err
+}
+
+func (p *pubSub) SSubscribe(ctx context.Context, channels ...string) error {
+	ctx = p.handler.before(ctx, CommandSSubscribe)
+	err := p.doShard(ctx, channels, true)
 	p.handler.after(ctx, err)
 	return err
 }
 
-func (p *pubSub) Channel() <-chan Message {
-	return p.msgCh
+func (p *pubSub) SUnsubscribe(ctx context.Context, channels ...string) error {
+	ctx = p.handler.before(ctx, CommandSUnsubscribe)
+	err := p.doShard(ctx, channels, false)
+	p.handler.after(ctx, err)
+	return err
+}
+
+// doShard 将分片频道按照节点分组，在每个节点的专用连接上订阅或者取消订阅
+func (p *pubSub) doShard(ctx context.Context, channels []string, subscribe bool) error {
+	if p.ctx.Err() != nil {
+		return ErrPubSubClosed
+	}
+	if !subscribe {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		for _, shard := range p.shards {
+			var names []string
+			for _, channel := range channels {
+				if _, ok := shard.channels[channel]; ok {
+					names = append(names, channel)
+				}
+			}
+			if len(channels) == 0 {
+				names = setKeys(shard.channels)
+			}
+			if len(names) == 0 {
+				continue
+			}
+			if err := p.doShardLocked(ctx, shard, names, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	groups, err := p.shardNodes(ctx, channels)
+	if err != nil {
+		return err
+	}
+	for node, names := range groups {
+		if err = p.subscribeShard(ctx, node, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shardNodes 将分片频道按照所在的主节点分组，非集群模式下只有一个分组
+func (p *pubSub) shardNodes(ctx context.Context, channels []string) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	if !p.handler.isCluster() {
+		groups[""] = channels
+		return groups, nil
+	}
+	slots, err := p.client.ClusterSlots(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		node := slotNode(slots, slot(channel))
+		if node == "" {
+			return nil, ErrPubSubNodeNotFound
+		}
+		groups[node] = append(groups[node], channel)
+	}
+	return groups, nil
+}
+
+func slotNode(slots []ClusterSlot, sl uint16) string {
+	for _, s := range slots {
+		if int64(sl) >= s.Start && int64(sl) <= s.End && len(s.Nodes) > 0 {
+			return s.Nodes[0].Addr
+		}
+	}
+	return ""
+}
+
+// subscribeShard 在节点的专用连接上订阅，节点没有连接时在 mu 之外获取，避免连接池耗尽时阻塞其他订阅
+func (p *pubSub) subscribeShard(ctx context.Context, node string, names []string) error {
+	var fresh *pubSubShard
+	var hookErr <-chan error
+	for {
+		p.mu.Lock()
+		if p.ctx.Err() != nil {
+			p.mu.Unlock()
+			if fresh != nil {
+				fresh.release()
+			}
+			return ErrPubSubClosed
+		}
+		shard := p.shards[node]
+		if shard == nil && fresh != nil {
+			shard, fresh = fresh, nil
+			p.shards[node] = shard
+			p.wg.Add(1)
+			go p.superviseShard(shard, hookErr)
+		}
+		if shard != nil {
+			err := p.doShardLocked(ctx, shard, names, true)
+			p.mu.Unlock()
+			if fresh != nil {
+				// 其他订阅已经创建了该节点的连接
+				fresh.release()
+			}
+			return err
+		}
+		p.mu.Unlock()
+		fresh = newPubSubShard(node)
+		var err error
+		if fresh.dc, fresh.release, hookErr, err = p.dedicateShard(fresh); err != nil {
+			return err
+		}
+	}
+}
+
+// doShardLocked 在节点的专用连接上按 slot 订阅或者取消订阅，没有订阅的频道时归还连接，调用方需持有 mu
+func (p *pubSub) doShardLocked(ctx context.Context, shard *pubSubShard, names []string, subscribe bool) error {
+	var err error
+	if shard.dc != nil {
+		err = doShardCmds(ctx, shard.dc, names, subscribe)
+	}
+	if _, ok := rueidis.IsRedisErr(err); !ok || !subscribe {
+		for _, name := range names {
+			if subscribe {
+				shard.channels[name] = struct{}{}
+			} else {
+				delete(shard.channels, name)
+			}
+		}
+	}
+	p.dropShardLocked(shard)
+	return err
+}
+
+// doShardCmds 同一个命令中的分片频道需位于同一个 slot，按 slot 拆分为多个命令
+func doShardCmds(ctx context.Context, dc rueidis.DedicatedClient, names []string, subscribe bool) error {
+	groups := make(map[uint16][]string)
+	for _, name := range names {
+		sl := slot(name)
+		groups[sl] = append(groups[sl], name)
+	}
+	cmds := make(rueidis.Commands, 0, len(groups))
+	for _, channels := range groups {
+		if subscribe {
+			cmds = append(cmds, dc.B().Ssubscribe().Channel(channels...).Build())
+		} else {
+			cmds = append(cmds, dc.B().Sunsubscribe().Channel(channels...).Build())
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	for _, resp := range dc.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropShardLocked 节点没有订阅的频道时结束监控并归还连接，调用方需持有 mu
+func (p *pubSub) dropShardLocked(shard *pubSubShard) {
+	if len(shard.channels) > 0 || p.shards[shard.node] != shard {
+		return
+	}
+	delete(p.shards, shard.node)
+	close(shard.quit)
+	shard.releaseLocked()
+}
+
+// dedicateShard 获取节点的专用连接并注册回调，非集群模式下使用默认的节点
+func (p *pubSub) dedicateShard(shard *pubSubShard) (rueidis.DedicatedClient, func(), <-chan error, error) {
+	cmd := p.client.cmd
+	if shard.node != "" {
+		var ok bool
+		if cmd, ok = p.client.cmd.Nodes()[shard.node]; !ok {
+			return nil, nil, nil, ErrPubSubNodeNotFound
+		}
+	}
+	dc, release := cmd.Dedicate()
+	hookErr := dc.SetPubSubHooks(rueidis.PubSubHooks{OnMessage: p.onMessage, OnSubscription: func(s Subscription) {
+		if s.Kind == "sunsubscribe" {
+			shard.markMoved(s.Channel)
+			return
+		}
+		p.onSubscription(s)
+	}})
+	return dc, release, hookErr, nil
+}
+
+// superviseShard 监控节点的专用连接，连接断开或者 slot 迁移后重新订阅，直到订阅结束或者该节点没有订阅的频道
+func (p *pubSub) superviseShard(shard *pubSubShard, hookErr <-chan error) {
+	defer p.wg.Done()
+	for {
+		var err error
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-shard.quit:
+			return
+		case <-shard.movedC:
+			if !p.shardMoved(shard) {
+				continue
+			}
+			err = ErrPubSubSlotMoved
+		case err = <-hookErr:
+		}
+		select {
+		case <-shard.quit:
+			return
+		default:
+		}
+		if p.ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = rueidis.ErrClosing
+		}
+		p.report(err)
+		if errors.Is(err, rueidis.ErrClosing) {
+			// 客户端已关闭
+			p.cancel()
+			return
+		}
+		if cb := p.spec.GetOnDisconnected(); cb != nil {
+			cb(err)
+		}
+		p.mu.Lock()
+		shard.releaseLocked()
+		p.mu.Unlock()
+		if hookErr = p.reconnect(func() (<-chan error, error) { return p.resubscribeShard(shard) }); hookErr == nil {
+			select {
+			case <-shard.quit:
+			default:
+				// 重新订阅失败次数超过 MaxRetries，结束整个订阅
+				p.cancel()
+			}
+			return
+		}
+	}
+}
+
+// shardMoved 服务端主动取消订阅的频道中，是否有仍需订阅的频道
+func (p *pubSub) shardMoved(shard *pubSubShard) bool {
+	moved := shard.takeMoved()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, channel := range moved {
+		if _, ok := shard.channels[channel]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resubscribeShard 将 slot 已迁移的频道转移到新节点的连接上，再获取新的专用连接重新订阅该节点的其余频道
+// 没有需要订阅的频道时返回 nil
+func (p *pubSub) resubscribeShard(shard *pubSubShard) (<-chan error, error) {
+	p.mu.Lock()
+	if p.ctx.Err() != nil || len(shard.channels) == 0 {
+		p.mu.Unlock()
+		return nil, nil
+	}
+	// 丢弃旧连接上收到的取消订阅
+	shard.takeMoved()
+	channels := setKeys(shard.channels)
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.client.v.GetWriteTimeout())
+	defer cancel()
+	groups, err := p.shardNodes(ctx, channels)
+	if err != nil {
+		return nil, err
+	}
+	for node, names := range groups {
+		if node == shard.node {
+			continue
+		}
+		if err = p.subscribeShard(ctx, node, names); err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+		for _, name := range names {
+			delete(shard.channels, name)
+		}
+		p.mu.Unlock()
+	}
+
+	dc, release, hookErr, err := p.dedicateShard(shard)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil || len(shard.channels) == 0 {
+		release()
+		p.dropShardLocked(shard)
+		return nil, nil
+	}
+	// 获取连接期间新增的频道同样在新连接上订阅
+	if err = doShardCmds(ctx, dc, setKeys(shard.channels), true); err != nil {
+		release()
+		return nil, err
+	}
+	shard.dc, shard.release = dc, release
+	return hookErr, nil
+}
+
+func (p *pubSub) Channel() <-chan Message { return p.msgCh }
+
+func (p *pubSub) Err() <-chan error { return p.errCh }
+
+func (p *pubSub) Done() <-chan struct{} { return p.done }
+
+func setKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// pubSubShard 同一个节点的分片频道共用一个专用连接，非集群模式下 node 为空
+type pubSubShard struct {
+	node     string
+	dc       rueidis.DedicatedClient
+	release  func()
+	channels map[string]struct{}
+	// quit 该节点没有订阅的频道时关闭
+	quit chan struct{}
+
+	// moved 服务端主动取消订阅的频道，通常由 slot 迁移导致，需要在新的节点上重新订阅
+	movedMu sync.Mutex
+	moved   []string
+	movedC  chan struct{}
+}
+
+func newPubSubShard(node string) *pubSubShard {
+	return &pubSubShard{
+		node:     node,
+		channels: make(map[string]struct{}),
+		quit:     make(chan struct{}),
+		movedC:   make(chan struct{}, 1),
+	}
+}
+
+// releaseLocked 归还专用连接，调用方需持有 pubSub 的 mu
+func (s *pubSubShard) releaseLocked() {
+	if s.release != nil {
+		s.release()
+	}
+	s.dc, s.release = nil, nil
+}
+
+func (s *pubSubShard) markMoved(channel string) {
+	s.movedMu.Lock()
+	s.moved = append(s.moved, channel)
+	s.movedMu.Unlock()
+	select {
+	case s.movedC <- struct{}{}:
+	default:
+	}
+}
+
+func (s *pubSubShard) takeMoved() []string {
+	s.movedMu.Lock()
+	defer s.movedMu.Unlock()
+	moved := s.moved
+	s.moved = nil
+	return moved
 }
@@ -2,6 +2,10 @@ package redisson
 
 import (
 	"context"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -169,3 +173,286 @@ func pubSubTestUnits() []TestUnit {
 }
 
 func TestResp2Client_PubSub(t *testing.T) { doTestUnits(t, pubSubTestUnits) }
+
+func TestPubSub_Resubscribe(t *testing.T) {
+	p := newTestProxy(t, "127.0.0.1:6379")
+	c := MustNewClient(NewConf(WithAddrs(p.addr()), WithDevelopment(false), WithEnableCache(false), WithForceSingleClient(true)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("resubscribe after disconnected", t, func() {
+		var subscribed, disconnected, resubscribed atomic.Int32
+		ps := c.NewPubSub(ctx,
+			WithPubSubOptionRetryPolicy(LinearBackoff(10*time.Millisecond, 50*time.Millisecond, 0)),
+			WithPubSubOptionOnSubscribed(func(Subscription) { subscribed.Add(1) }),
+			WithPubSubOptionOnDisconnected(func(error) { disconnected.Add(1) }),
+			WithPubSubOptionOnResubscribed(func(int) { resubscribed.Add(1) }),
+		)
+		So(ps.Subscribe(ctx, "channel"), ShouldBeNil)
+		So(ps.PSubscribe(ctx, "pattern.*"), ShouldBeNil)
+		So(subscribed.Load(), ShouldEqual, 2)
+
+		// 断开所有连接
+		p.disconnect(false)
+		select {
+		case err := <-ps.Err():
+			So(err, ShouldNotBeNil)
+		case <-time.After(time.Second):
+			So("disconnection not reported", ShouldBeEmpty)
+		}
+		So(waitFor(func() bool { return resubscribed.Load() == 1 }), ShouldBeTrue)
+		So(disconnected.Load(), ShouldEqual, 1)
+		So(subscribed.Load(), ShouldEqual, 4)
+
+		// 共享连接同样被断开，重连之后才能发布
+		So(waitFor(func() bool { return c.Publish(ctx, "channel", "one").Err() == nil }), ShouldBeTrue)
+		So(c.Publish(ctx, "pattern.a", "two").Err(), ShouldBeNil)
+		for _, want := range []string{"one", "two"} {
+			select {
+			case msg := <-ps.Channel():
+				So(msg.Message, ShouldEqual, want)
+			case <-time.After(time.Second):
+				So("message not received", ShouldBeEmpty)
+			}
+		}
+
+		So(ps.Close(), ShouldBeNil)
+		<-ps.Done()
+		_, ok := <-ps.Channel()
+		So(ok, ShouldBeFalse)
+		So(ps.Subscribe(ctx, "channel"), ShouldEqual, ErrPubSubClosed)
+	})
+
+	Convey("stop after max retries", t, func() {
+		ps := c.NewPubSub(ctx,
+			WithPubSubOptionRetryPolicy(LinearBackoff(10*time.Millisecond, 10*time.Millisecond, 0)),
+			WithPubSubOptionMaxRetries(2),
+		)
+		So(ps.Subscribe(ctx, "channel"), ShouldBeNil)
+		p.disconnect(true)
+		defer p.disconnect(false)
+		select {
+		case <-ps.Done():
+		case <-time.After(5 * time.Second):
+			So("subscription not done", ShouldBeEmpty)
+		}
+	})
+}
+
+func waitFor(cond func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestPubSub_Overflow(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	var receive = func(ps PubSub, opts ...PubSubOption) []string {
+		So(ps.Subscribe(ctx, "overflow"), ShouldBeNil)
+		for _, m := range []string{"1", "2", "3", "4", "5"} {
+			So(c.Publish(ctx, "overflow", m).Err(), ShouldBeNil)
+		}
+		time.Sleep(50 * time.Millisecond)
+		So(ps.Close(), ShouldBeNil)
+		var msgs []string
+		for msg := range ps.Channel() {
+			msgs = append(msgs, msg.Message)
+		}
+		return msgs
+	}
+
+	Convey("drop newest", t, func() {
+		ps := c.NewPubSub(ctx, WithPubSubOptionChannelSize(2), WithPubSubOptionOverflowPolicy(PubSubOverflowDropNewest))
+		So(receive(ps), ShouldResemble, []string{"1", "2"})
+	})
+
+	Convey("drop oldest", t, func() {
+		ps := c.NewPubSub(ctx, WithPubSubOptionChannelSize(2), WithPubSubOptionOverflowPolicy(PubSubOverflowDropOldest))
+		So(receive(ps), ShouldResemble, []string{"4", "5"})
+	})
+
+	Convey("callback", t, func() {
+		var overflow []string
+		ps := c.NewPubSub(ctx, WithPubSubOptionChannelSize(2), WithPubSubOptionOverflowPolicy(PubSubOverflowCallback),
+			WithPubSubOptionOnOverflow(func(m Message) { overflow = append(overflow, m.Message) }))
+		So(receive(ps), ShouldResemble, []string{"1", "2"})
+		So(overflow, ShouldResemble, []string{"3", "4", "5"})
+	})
+
+	Convey("block with send timeout", t, func() {
+		ps := c.NewPubSub(ctx, WithPubSubOptionChannelSize(2), WithPubSubOptionSendTimeout(5*time.Millisecond))
+		So(receive(ps), ShouldResemble, []string{"1", "2"})
+	})
+
+	Convey("close while blocking", t, func() {
+		ps := c.NewPubSub(ctx, WithPubSubOptionChannelSize(2))
+		start := time.Now()
+		So(receive(ps), ShouldResemble, []string{"1", "2"})
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+	})
+}
+
+func TestPubSub_SSubscribe(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false), WithEnableCache(false), WithForceSingleClient(true)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	// 不同 slot 的消息来自不同的连接，不保证顺序
+	var receive = func(ps PubSub, want ...string) {
+		var got []string
+		for range want {
+			select {
+			case msg := <-ps.Channel():
+				got = append(got, msg.Channel+":"+msg.Message)
+			case <-time.After(time.Second):
+				So("message not received", ShouldBeEmpty)
+			}
+		}
+		So(stringSliceEqual(got, want, false), ShouldBeTrue)
+	}
+
+	Convey("sharded subscribe", t, func() {
+		var disconnected atomic.Value
+		var resubscribed atomic.Int32
+		ps := c.NewPubSub(ctx,
+			WithPubSubOptionRetryPolicy(LinearBackoff(10*time.Millisecond, 50*time.Millisecond, 0)),
+			WithPubSubOptionOnDisconnected(func(err error) { disconnected.Store(err) }),
+			WithPubSubOptionOnResubscribed(func(int) { resubscribed.Add(1) }),
+		)
+		So(ps.SSubscribe(ctx, "shard1", "shard2", "{shard1}.a"), ShouldBeNil)
+		So(ps.Subscribe(ctx, "channel"), ShouldBeNil)
+
+		So(c.SPublish(ctx, "shard1", "one").Err(), ShouldBeNil)
+		So(c.SPublish(ctx, "shard2", "two").Err(), ShouldBeNil)
+		So(c.SPublish(ctx, "{shard1}.a", "three").Err(), ShouldBeNil)
+		So(c.Publish(ctx, "channel", "four").Err(), ShouldBeNil)
+		receive(ps, "shard1:one", "shard2:two", "{shard1}.a:three", "channel:four")
+
+		// 非集群模式下所有的分片频道共用一个连接
+		impl := ps.(*pubSub)
+		impl.mu.Lock()
+		So(len(impl.shards), ShouldEqual, 1)
+		shard := impl.shards[""]
+		impl.mu.Unlock()
+		So(shard, ShouldNotBeNil)
+
+		// 未订阅的频道被取消订阅时不需要重新订阅
+		shard.markMoved("shard9")
+		So(impl.shardMoved(shard), ShouldBeFalse)
+
+		// slot 迁移时服务端主动取消订阅，重新获取连接并订阅
+		shard.markMoved("shard1")
+		So(waitFor(func() bool { return resubscribed.Load() == 1 }), ShouldBeTrue)
+		So(disconnected.Load(), ShouldEqual, ErrPubSubSlotMoved)
+		So(c.SPublish(ctx, "{shard1}.a", "five").Err(), ShouldBeNil)
+		receive(ps, "{shard1}.a:five")
+
+		So(ps.SUnsubscribe(ctx, "shard2"), ShouldBeNil)
+		So(c.SPublish(ctx, "shard2", "six").Err(), ShouldBeNil)
+		So(c.SPublish(ctx, "shard1", "seven").Err(), ShouldBeNil)
+		receive(ps, "shard1:seven")
+
+		So(ps.SUnsubscribe(ctx), ShouldBeNil)
+		So(ps.Close(), ShouldBeNil)
+	})
+
+	Convey("sharded receive", t, func() {
+		ctx, cancel := context.WithCancel(ctx)
+		received := make(chan string, 1)
+		go func() {
+			_ = c.SReceive(ctx, func(msg Message) { received <- msg.Message }, "shard3", "shard4")
+		}()
+		So(waitFor(func() bool { return c.SPublish(ctx, "shard3", "one").Val() == 1 }), ShouldBeTrue)
+		So(<-received, ShouldEqual, "one")
+		So(c.SPublish(ctx, "shard4", "two").Err(), ShouldBeNil)
+		So(<-received, ShouldEqual, "two")
+		cancel()
+	})
+
+	Convey("slot node", t, func() {
+		slots := []ClusterSlot{
+			{Start: 0, End: 8191, Nodes: []ClusterNode{{Addr: "127.0.0.1:7000"}, {Addr: "127.0.0.1:7002"}}},
+			{Start: 8192, End: 16383, Nodes: []ClusterNode{{Addr: "127.0.0.1:7001"}}},
+		}
+		So(slotNode(slots, 0), ShouldEqual, "127.0.0.1:7000")
+		So(slotNode(slots, 8192), ShouldEqual, "127.0.0.1:7001")
+		So(slotNode(slots[:1], 8192), ShouldBeEmpty)
+	})
+}
+
+// testProxy 转发到 Redis 的 TCP 代理，用于模拟连接断开
+type testProxy struct {
+	ln     net.Listener
+	target string
+
+	mu     sync.Mutex
+	conns  []net.Conn
+	refuse bool
+}
+
+func newTestProxy(t *testing.T, target string) *testProxy {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed, %v", err)
+	}
+	p := &testProxy{ln: ln, target: target}
+	t.Cleanup(func() {
+		_ = ln.Close()
+		p.disconnect(false)
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p
+}
+
+func (p *testProxy) addr() string { return p.ln.Addr().String() }
+
+func (p *testProxy) serve(conn net.Conn) {
+	p.mu.Lock()
+	if p.refuse {
+		p.mu.Unlock()
+		_ = conn.Close()
+		return
+	}
+	upstream, err := net.Dial("tcp", p.target)
+	if err != nil {
+		p.mu.Unlock()
+		_ = conn.Close()
+		return
+	}
+	p.conns = append(p.conns, conn, upstream)
+	p.mu.Unlock()
+	go func() { _, _ = io.Copy(upstream, conn); _ = upstream.Close() }()
+	_, _ = io.Copy(conn, upstream)
+	_ = conn.Close()
+}
+
+// disconnect 断开所有连接，refuse 为 true 时拒绝之后的连接
+func (p *testProxy) disconnect(refuse bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.conns, p.refuse = nil, refuse
+}
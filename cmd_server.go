@@ -347,7 +347,7 @@ func (c *client) LastSave(ctx context.Context) IntCmd {
 }
 
 func (c *client) MemoryUsage(ctx context.Context, key string, samples ...int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandMemoryUsage)
+	ctx = c.handler.beforeWithKeys(ctx, CommandMemoryUsage, func() []string { return []string{key} })
 	r := c.adapter.MemoryUsage(ctx, key, samples...)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -389,7 +389,7 @@ func (c *client) Time(ctx context.Context) TimeCmd {
 }
 
 func (c *client) DebugObject(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandDebugObject)
+	ctx = c.handler.beforeWithKeys(ctx, CommandDebugObject, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.DebugObjectCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -191,9 +191,9 @@ type SetCacheCmdable interface {
 
 func (c *client) SAdd(ctx context.Context, key string, members ...any) IntCmd {
 	if len(members) > 1 {
-		ctx = c.handler.before(ctx, CommandSMAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSMAdd, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSAdd, func() []string { return []string{key} })
 	}
 	r := c.adapter.SAdd(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
@@ -201,7 +201,7 @@ func (c *client) SAdd(ctx context.Context, key string, members ...any) IntCmd {
 }
 
 func (c *client) SCard(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandSCard)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSCard, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.SCardCompleted(key)))
@@ -248,7 +248,7 @@ func (c *client) SInterCard(ctx context.Context, limit int64, keys ...string) In
 }
 
 func (c *client) SIsMember(ctx context.Context, key string, member any) BoolCmd {
-	ctx = c.handler.before(ctx, CommandSIsMember)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSIsMember, func() []string { return []string{key} })
 	var r BoolCmd
 	if c.ttl > 0 {
 		r = newBoolCmd(c.Do(ctx, c.builder.SIsMemberCompleted(key, member)))
@@ -260,7 +260,7 @@ func (c *client) SIsMember(ctx context.Context, key string, member any) BoolCmd
 }
 
 func (c *client) SMIsMember(ctx context.Context, key string, members ...any) BoolSliceCmd {
-	ctx = c.handler.before(ctx, CommandSMIsMember)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSMIsMember, func() []string { return []string{key} })
 	var r BoolSliceCmd
 	if c.ttl > 0 {
 		r = newBoolSliceCmd(c.Do(ctx, c.builder.SMIsMemberCompleted(key, members...)))
@@ -272,7 +272,7 @@ func (c *client) SMIsMember(ctx context.Context, key string, members ...any) Boo
 }
 
 func (c *client) SMembers(ctx context.Context, key string) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandSMembers)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSMembers, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.SMembersCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -286,28 +286,28 @@ func (c *client) SMove(ctx context.Context, source, destination string, member a
 }
 
 func (c *client) SPop(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandSPop)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSPop, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.SPopCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) SPopN(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandSPopN)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSPopN, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.SPopNCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) SRandMember(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandSRandMember)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSRandMember, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.SRandMemberCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) SRandMemberN(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandSRandMemberN)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSRandMemberN, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.SRandMemberNCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
@@ -315,9 +315,9 @@ func (c *client) SRandMemberN(ctx context.Context, key string, count int64) Stri
 
 func (c *client) SRem(ctx context.Context, key string, members ...any) IntCmd {
 	if len(members) > 1 {
-		ctx = c.handler.before(ctx, CommandSMRem)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSMRem, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSRem)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSRem, func() []string { return []string{key} })
 	}
 	r := c.adapter.SRem(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
@@ -325,7 +325,7 @@ func (c *client) SRem(ctx context.Context, key string, members ...any) IntCmd {
 }
 
 func (c *client) SScan(ctx context.Context, key string, cursor uint64, match string, count int64) ScanCmd {
-	ctx = c.handler.before(ctx, CommandSScan)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSScan, func() []string { return []string{key} })
 	r := c.adapter.SScan(ctx, key, cursor, match, count)
 	c.handler.after(ctx, r.Err())
 	return r
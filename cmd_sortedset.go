@@ -449,9 +449,9 @@ func (c *client) BZPopMin(ctx context.Context, timeout time.Duration, keys ...st
 
 func (c *client) ZAdd(ctx context.Context, key string, members ...Z) IntCmd {
 	if len(members) > 1 {
-		ctx = c.handler.before(ctx, CommandZMAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZMAdd, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandZAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAdd, func() []string { return []string{key} })
 	}
 	r := c.adapter.ZAdd(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
@@ -459,28 +459,28 @@ func (c *client) ZAdd(ctx context.Context, key string, members ...Z) IntCmd {
 }
 
 func (c *client) ZAddLT(ctx context.Context, key string, members ...Z) IntCmd {
-	ctx = c.handler.before(ctx, CommandZAddLT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZAddLT, func() []string { return []string{key} })
 	r := c.adapter.ZAddLT(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZAddGT(ctx context.Context, key string, members ...Z) IntCmd {
-	ctx = c.handler.before(ctx, CommandZAddGT)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZAddGT, func() []string { return []string{key} })
 	r := c.adapter.ZAddGT(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZAddNX(ctx context.Context, key string, members ...Z) IntCmd {
-	ctx = c.handler.before(ctx, CommandZAddNX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZAddNX, func() []string { return []string{key} })
 	r := c.adapter.ZAddNX(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZAddXX(ctx context.Context, key string, members ...Z) IntCmd {
-	ctx = c.handler.before(ctx, CommandZAddXX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZAddXX, func() []string { return []string{key} })
 	r := c.adapter.ZAddXX(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -488,19 +488,19 @@ func (c *client) ZAddXX(ctx context.Context, key string, members ...Z) IntCmd {
 
 func (c *client) ZAddArgs(ctx context.Context, key string, args ZAddArgs) IntCmd {
 	if args.GT {
-		ctx = c.handler.before(ctx, CommandZAddGT)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddGT, func() []string { return []string{key} })
 	} else if args.LT {
-		ctx = c.handler.before(ctx, CommandZAddLT)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddLT, func() []string { return []string{key} })
 	} else if args.Ch {
-		ctx = c.handler.before(ctx, CommandZAddCh)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddCh, func() []string { return []string{key} })
 	} else if args.NX {
-		ctx = c.handler.before(ctx, CommandZAddNX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddNX, func() []string { return []string{key} })
 	} else if args.XX {
-		ctx = c.handler.before(ctx, CommandZAddXX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddXX, func() []string { return []string{key} })
 	} else if len(args.Members) > 1 {
-		ctx = c.handler.before(ctx, CommandZMAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZMAdd, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandZAdd)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAdd, func() []string { return []string{key} })
 	}
 	r := c.adapter.ZAddArgs(ctx, key, args)
 	c.handler.after(ctx, r.Err())
@@ -509,11 +509,11 @@ func (c *client) ZAddArgs(ctx context.Context, key string, args ZAddArgs) IntCmd
 
 func (c *client) ZAddArgsIncr(ctx context.Context, key string, args ZAddArgs) FloatCmd {
 	if args.GT {
-		ctx = c.handler.before(ctx, CommandZAddGT)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddGT, func() []string { return []string{key} })
 	} else if args.LT {
-		ctx = c.handler.before(ctx, CommandZAddLT)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddLT, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandZAddIncr)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZAddIncr, func() []string { return []string{key} })
 	}
 	r := c.adapter.ZAddArgsIncr(ctx, key, args)
 	c.handler.after(ctx, r.Err())
@@ -521,7 +521,7 @@ func (c *client) ZAddArgsIncr(ctx context.Context, key string, args ZAddArgs) Fl
 }
 
 func (c *client) ZCard(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZCard)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZCard, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.ZCardCompleted(key)))
@@ -533,7 +533,7 @@ func (c *client) ZCard(ctx context.Context, key string) IntCmd {
 }
 
 func (c *client) ZCount(ctx context.Context, key, min, max string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZCount, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.ZCountCompleted(key, min, max)))
@@ -566,7 +566,7 @@ func (c *client) ZDiffStore(ctx context.Context, destination string, keys ...str
 }
 
 func (c *client) ZIncrBy(ctx context.Context, key string, increment float64, member string) FloatCmd {
-	ctx = c.handler.before(ctx, CommandZIncrBy)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZIncrBy, func() []string { return []string{key} })
 	r := c.adapter.ZIncrBy(ctx, key, increment, member)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -601,7 +601,7 @@ func (c *client) ZInterCard(ctx context.Context, limit int64, keys ...string) In
 }
 
 func (c *client) ZLexCount(ctx context.Context, key, min, max string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZLexCount)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZLexCount, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.ZLexCountCompleted(key, min, max)))
@@ -620,7 +620,7 @@ func (c *client) ZMPop(ctx context.Context, order string, count int64, keys ...s
 }
 
 func (c *client) ZMScore(ctx context.Context, key string, members ...string) FloatSliceCmd {
-	ctx = c.handler.before(ctx, CommandZMScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZMScore, func() []string { return []string{key} })
 	var r FloatSliceCmd
 	if c.ttl > 0 {
 		r = newFloatSliceCmd(c.Do(ctx, c.builder.ZMScoreCompleted(key, members...)))
@@ -632,42 +632,42 @@ func (c *client) ZMScore(ctx context.Context, key string, members ...string) Flo
 }
 
 func (c *client) ZPopMax(ctx context.Context, key string, count ...int64) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZPopMax)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZPopMax, func() []string { return []string{key} })
 	r := c.adapter.ZPopMax(ctx, key, count...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZPopMin(ctx context.Context, key string, count ...int64) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZPopMin)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZPopMin, func() []string { return []string{key} })
 	r := c.adapter.ZPopMin(ctx, key, count...)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRandMember(ctx context.Context, key string, count int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRandMember)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRandMember, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRandMemberCompleted(key, count)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRandMemberWithScores(ctx context.Context, key string, count int64) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRandMemberWithScores)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRandMemberWithScores, func() []string { return []string{key} })
 	r := c.adapter.ZRandMemberWithScores(ctx, key, count)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRange(ctx context.Context, key string, start, stop int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRange, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRangeCompleted(key, start, stop)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRangeWithScores(ctx context.Context, key string, start, stop int64) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRange, func() []string { return []string{key} })
 	var r ZSliceCmd
 	if c.ttl > 0 {
 		r = newZSliceCmd(c.Do(ctx, c.builder.ZRangeWithScoresCompleted(key, start, stop)))
@@ -706,21 +706,21 @@ func (c *client) ZRangeArgsWithScores(ctx context.Context, z ZRangeArgs) ZSliceC
 }
 
 func (c *client) ZRangeByLex(ctx context.Context, key string, opt ZRangeBy) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRangeByLex)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRangeByLex, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRangeByLexCompleted(key, opt)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRangeByScore(ctx context.Context, key string, opt ZRangeBy) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRangeByScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRangeByScore, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRangeByScoreCompleted(key, opt)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRangeByScoreWithScores(ctx context.Context, key string, opt ZRangeBy) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRangeByScoreWithScores)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRangeByScoreWithScores, func() []string { return []string{key} })
 	var r ZSliceCmd
 	if c.ttl > 0 {
 		r = newZSliceCmd(c.Do(ctx, c.builder.ZRangeByScoreWithScoresCompleted(key, opt)))
@@ -739,7 +739,7 @@ func (c *client) ZRangeStore(ctx context.Context, dst string, z ZRangeArgs) IntC
 }
 
 func (c *client) ZRank(ctx context.Context, key, member string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZRank)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRank, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.ZRankCompleted(key, member)))
@@ -751,7 +751,7 @@ func (c *client) ZRank(ctx context.Context, key, member string) IntCmd {
 }
 
 func (c *client) ZRankWithScore(ctx context.Context, key, member string) RankWithScoreCmd {
-	ctx = c.handler.before(ctx, CommandZRank)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRank, func() []string { return []string{key} })
 	var r RankWithScoreCmd
 	if c.ttl > 0 {
 		r = newRankWithScoreCmd(c.Do(ctx, c.builder.ZRankWithScoreCompleted(key, member)))
@@ -764,9 +764,9 @@ func (c *client) ZRankWithScore(ctx context.Context, key, member string) RankWit
 
 func (c *client) ZRem(ctx context.Context, key string, members ...any) IntCmd {
 	if len(members) > 1 {
-		ctx = c.handler.before(ctx, CommandZMRem)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZMRem, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandZRem)
+		ctx = c.handler.beforeWithKeys(ctx, CommandZRem, func() []string { return []string{key} })
 	}
 	r := c.adapter.ZRem(ctx, key, members...)
 	c.handler.after(ctx, r.Err())
@@ -774,35 +774,35 @@ func (c *client) ZRem(ctx context.Context, key string, members ...any) IntCmd {
 }
 
 func (c *client) ZRemRangeByLex(ctx context.Context, key, min, max string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZRemRangeByLex)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRemRangeByLex, func() []string { return []string{key} })
 	r := c.adapter.ZRemRangeByLex(ctx, key, min, max)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandZRemRangeByRank)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRemRangeByRank, func() []string { return []string{key} })
 	r := c.adapter.ZRemRangeByRank(ctx, key, start, stop)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRemRangeByScore(ctx context.Context, key, min, max string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZRemRangeByScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRemRangeByScore, func() []string { return []string{key} })
 	r := c.adapter.ZRemRangeByScore(ctx, key, min, max)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRevRange(ctx context.Context, key string, start, stop int64) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRevRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRange, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRevRangeCompleted(key, start, stop)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRevRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRange, func() []string { return []string{key} })
 	var r ZSliceCmd
 	if c.ttl > 0 {
 		r = newZSliceCmd(c.Do(ctx, c.builder.ZRevRangeWithScoresCompleted(key, start, stop)))
@@ -814,21 +814,21 @@ func (c *client) ZRevRangeWithScores(ctx context.Context, key string, start, sto
 }
 
 func (c *client) ZRevRangeByLex(ctx context.Context, key string, opt ZRangeBy) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRevRangeByLex)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRangeByLex, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRevRangeByLexCompleted(key, opt)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRevRangeByScore(ctx context.Context, key string, opt ZRangeBy) StringSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRevRangeByScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRangeByScore, func() []string { return []string{key} })
 	r := newStringSliceCmd(c.Do(ctx, c.builder.ZRevRangeByScoreCompleted(key, opt)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZRevRangeByScoreWithScores(ctx context.Context, key string, opt ZRangeBy) ZSliceCmd {
-	ctx = c.handler.before(ctx, CommandZRevRangeByScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRangeByScore, func() []string { return []string{key} })
 	var r ZSliceCmd
 	if c.ttl > 0 {
 		r = newZSliceCmd(c.Do(ctx, c.builder.ZRevRangeByScoreWithScoresCompleted(key, opt)))
@@ -840,7 +840,7 @@ func (c *client) ZRevRangeByScoreWithScores(ctx context.Context, key string, opt
 }
 
 func (c *client) ZRevRank(ctx context.Context, key, member string) IntCmd {
-	ctx = c.handler.before(ctx, CommandZRevRank)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRank, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.ZRevRankCompleted(key, member)))
@@ -852,7 +852,7 @@ func (c *client) ZRevRank(ctx context.Context, key, member string) IntCmd {
 }
 
 func (c *client) ZRevRankWithScore(ctx context.Context, key, member string) RankWithScoreCmd {
-	ctx = c.handler.before(ctx, CommandZRevRank)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZRevRank, func() []string { return []string{key} })
 	var r RankWithScoreCmd
 	if c.ttl > 0 {
 		r = newRankWithScoreCmd(c.Do(ctx, c.builder.ZRevRankWithScoreCompleted(key, member)))
@@ -864,14 +864,14 @@ func (c *client) ZRevRankWithScore(ctx context.Context, key, member string) Rank
 }
 
 func (c *client) ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) ScanCmd {
-	ctx = c.handler.before(ctx, CommandZScan)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZScan, func() []string { return []string{key} })
 	r := c.adapter.ZScan(ctx, key, cursor, match, count)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) ZScore(ctx context.Context, key, member string) FloatCmd {
-	ctx = c.handler.before(ctx, CommandZScore)
+	ctx = c.handler.beforeWithKeys(ctx, CommandZScore, func() []string { return []string{key} })
 	var r FloatCmd
 	if c.ttl > 0 {
 		r = newFloatCmd(c.Do(ctx, c.builder.ZScoreCompleted(key, member)))
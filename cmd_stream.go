@@ -362,28 +362,28 @@ func (c *client) XGroupSetID(ctx context.Context, stream, group, start string) S
 }
 
 func (c *client) XInfoConsumers(ctx context.Context, key string, group string) XInfoConsumersCmd {
-	ctx = c.handler.before(ctx, CommandXInfoConsumers)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXInfoConsumers, func() []string { return []string{key} })
 	r := c.adapter.XInfoConsumers(ctx, key, group)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XInfoGroups(ctx context.Context, key string) XInfoGroupsCmd {
-	ctx = c.handler.before(ctx, CommandXInfoGroups)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXInfoGroups, func() []string { return []string{key} })
 	r := c.adapter.XInfoGroups(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XInfoStream(ctx context.Context, key string) XInfoStreamCmd {
-	ctx = c.handler.before(ctx, CommandXInfoStream)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXInfoStream, func() []string { return []string{key} })
 	r := c.adapter.XInfoStream(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XInfoStreamFull(ctx context.Context, key string, count int64) XInfoStreamFullCmd {
-	ctx = c.handler.before(ctx, CommandXInfoStreamFull)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXInfoStreamFull, func() []string { return []string{key} })
 	r := c.adapter.XInfoStreamFull(ctx, key, count)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -460,21 +460,21 @@ func (c *client) XRevRangeN(ctx context.Context, stream string, stop, start stri
 }
 
 func (c *client) XTrim(ctx context.Context, key string, maxLen int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandXTrim)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXTrim, func() []string { return []string{key} })
 	r := c.adapter.XTrimMaxLen(ctx, key, maxLen)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XTrimApprox(ctx context.Context, key string, maxLen int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandXTrim)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXTrim, func() []string { return []string{key} })
 	r := c.adapter.XTrimMaxLenApprox(ctx, key, maxLen, 0)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XTrimMaxLen(ctx context.Context, key string, maxLen int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandXTrim)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXTrim, func() []string { return []string{key} })
 	r := c.adapter.XTrimMaxLen(ctx, key, maxLen)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -482,9 +482,9 @@ func (c *client) XTrimMaxLen(ctx context.Context, key string, maxLen int64) IntC
 
 func (c *client) XTrimMaxLenApprox(ctx context.Context, key string, maxLen, limit int64) IntCmd {
 	if limit > 0 {
-		ctx = c.handler.before(ctx, CommandXTrimMaxLenApprox)
+		ctx = c.handler.beforeWithKeys(ctx, CommandXTrimMaxLenApprox, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandXTrim)
+		ctx = c.handler.beforeWithKeys(ctx, CommandXTrim, func() []string { return []string{key} })
 	}
 	r := c.adapter.XTrimMaxLenApprox(ctx, key, maxLen, limit)
 	c.handler.after(ctx, r.Err())
@@ -492,14 +492,14 @@ func (c *client) XTrimMaxLenApprox(ctx context.Context, key string, maxLen, limi
 }
 
 func (c *client) XTrimMinID(ctx context.Context, key string, minID string) IntCmd {
-	ctx = c.handler.before(ctx, CommandXTrimMinID)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXTrimMinID, func() []string { return []string{key} })
 	r := c.adapter.XTrimMinID(ctx, key, minID)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) XTrimMinIDApprox(ctx context.Context, key string, minID string, limit int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandXTrimMinIDApprox)
+	ctx = c.handler.beforeWithKeys(ctx, CommandXTrimMinIDApprox, func() []string { return []string{key} })
 	r := c.adapter.XTrimMinIDApprox(ctx, key, minID, limit)
 	c.handler.after(ctx, r.Err())
 	return r
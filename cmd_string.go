@@ -234,77 +234,77 @@ type StringCacheCmdable interface {
 }
 
 func (c *client) Append(ctx context.Context, key, value string) IntCmd {
-	ctx = c.handler.before(ctx, CommandAppend)
+	ctx = c.handler.beforeWithKeys(ctx, CommandAppend, func() []string { return []string{key} })
 	r := c.adapter.Append(ctx, key, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) Decr(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandDecr)
+	ctx = c.handler.beforeWithKeys(ctx, CommandDecr, func() []string { return []string{key} })
 	r := c.adapter.Decr(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) DecrBy(ctx context.Context, key string, decrement int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandDecrBy)
+	ctx = c.handler.beforeWithKeys(ctx, CommandDecrBy, func() []string { return []string{key} })
 	r := c.adapter.DecrBy(ctx, key, decrement)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) Get(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandGet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGet, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.GetCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GetDel(ctx context.Context, key string) StringCmd {
-	ctx = c.handler.before(ctx, CommandGetDel)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGetDel, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.GetDelCompleted(key)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GetEx(ctx context.Context, key string, expiration time.Duration) StringCmd {
-	ctx = c.handler.before(ctx, CommandGetEx)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGetEx, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.GetExCompleted(key, expiration)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GetRange(ctx context.Context, key string, start, end int64) StringCmd {
-	ctx = c.handler.before(ctx, CommandGetRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGetRange, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.GetRangeCompleted(key, start, end)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) GetSet(ctx context.Context, key string, value any) StringCmd {
-	ctx = c.handler.before(ctx, CommandGetSet)
+	ctx = c.handler.beforeWithKeys(ctx, CommandGetSet, func() []string { return []string{key} })
 	r := newStringCmd(c.Do(ctx, c.builder.GetSetCompleted(key, value)))
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) Incr(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandIncr)
+	ctx = c.handler.beforeWithKeys(ctx, CommandIncr, func() []string { return []string{key} })
 	r := c.adapter.Incr(ctx, key)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) IncrBy(ctx context.Context, key string, value int64) IntCmd {
-	ctx = c.handler.before(ctx, CommandIncrBy)
+	ctx = c.handler.beforeWithKeys(ctx, CommandIncrBy, func() []string { return []string{key} })
 	r := c.adapter.IncrBy(ctx, key, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) IncrByFloat(ctx context.Context, key string, value float64) FloatCmd {
-	ctx = c.handler.before(ctx, CommandIncrByFloat)
+	ctx = c.handler.beforeWithKeys(ctx, CommandIncrByFloat, func() []string { return []string{key} })
 	r := c.adapter.IncrByFloat(ctx, key, value)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -333,9 +333,9 @@ func (c *client) MSetNX(ctx context.Context, values ...any) BoolCmd {
 
 func (c *client) Set(ctx context.Context, key string, value any, expiration time.Duration) StatusCmd {
 	if expiration == KeepTTL {
-		ctx = c.handler.before(ctx, CommandSetKeepTTL)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetKeepTTL, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSet)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSet, func() []string { return []string{key} })
 	}
 	r := c.adapter.Set(ctx, key, value, expiration)
 	c.handler.after(ctx, r.Err())
@@ -343,7 +343,7 @@ func (c *client) Set(ctx context.Context, key string, value any, expiration time
 }
 
 func (c *client) SetEX(ctx context.Context, key string, value any, expiration time.Duration) StatusCmd {
-	ctx = c.handler.before(ctx, CommandSetEX)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSetEX, func() []string { return []string{key} })
 	r := c.adapter.SetEX(ctx, key, value, expiration)
 	c.handler.after(ctx, r.Err())
 	return r
@@ -351,9 +351,9 @@ func (c *client) SetEX(ctx context.Context, key string, value any, expiration ti
 
 func (c *client) SetNX(ctx context.Context, key string, value any, expiration time.Duration) BoolCmd {
 	if expiration == KeepTTL {
-		ctx = c.handler.before(ctx, CommandSetKeepTTL)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetKeepTTL, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSetNX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetNX, func() []string { return []string{key} })
 	}
 	r := c.adapter.SetNX(ctx, key, value, expiration)
 	c.handler.after(ctx, r.Err())
@@ -362,9 +362,9 @@ func (c *client) SetNX(ctx context.Context, key string, value any, expiration ti
 
 func (c *client) SetXX(ctx context.Context, key string, value any, expiration time.Duration) BoolCmd {
 	if expiration == KeepTTL {
-		ctx = c.handler.before(ctx, CommandSetKeepTTL)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetKeepTTL, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSetXX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetXX, func() []string { return []string{key} })
 	}
 	r := c.adapter.SetXX(ctx, key, value, expiration)
 	c.handler.after(ctx, r.Err())
@@ -374,19 +374,19 @@ func (c *client) SetXX(ctx context.Context, key string, value any, expiration ti
 func (c *client) SetArgs(ctx context.Context, key string, value any, a SetArgs) StatusCmd {
 	m := strings.ToUpper(a.Mode)
 	if a.Get && m == NX {
-		ctx = c.handler.before(ctx, CommandSetNXGet)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetNXGet, func() []string { return []string{key} })
 	} else if a.Get || !a.ExpireAt.IsZero() {
-		ctx = c.handler.before(ctx, CommandSetGet)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetGet, func() []string { return []string{key} })
 	} else if a.KeepTTL {
-		ctx = c.handler.before(ctx, CommandSetKeepTTL)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetKeepTTL, func() []string { return []string{key} })
 	} else if m == NX {
-		ctx = c.handler.before(ctx, CommandSetArgsNX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetArgsNX, func() []string { return []string{key} })
 	} else if m == XX {
-		ctx = c.handler.before(ctx, CommandSetXX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetXX, func() []string { return []string{key} })
 	} else if a.TTL > 0 {
-		ctx = c.handler.before(ctx, CommandSetArgsEX)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSetArgsEX, func() []string { return []string{key} })
 	} else {
-		ctx = c.handler.before(ctx, CommandSet)
+		ctx = c.handler.beforeWithKeys(ctx, CommandSet, func() []string { return []string{key} })
 	}
 	r := c.adapter.SetArgs(ctx, key, value, a)
 	c.handler.after(ctx, r.Err())
@@ -394,14 +394,14 @@ func (c *client) SetArgs(ctx context.Context, key string, value any, a SetArgs)
 }
 
 func (c *client) SetRange(ctx context.Context, key string, offset int64, value string) IntCmd {
-	ctx = c.handler.before(ctx, CommandSetRange)
+	ctx = c.handler.beforeWithKeys(ctx, CommandSetRange, func() []string { return []string{key} })
 	r := c.adapter.SetRange(ctx, key, offset, value)
 	c.handler.after(ctx, r.Err())
 	return r
 }
 
 func (c *client) StrLen(ctx context.Context, key string) IntCmd {
-	ctx = c.handler.before(ctx, CommandStrLen)
+	ctx = c.handler.beforeWithKeys(ctx, CommandStrLen, func() []string { return []string{key} })
 	var r IntCmd
 	if c.ttl > 0 {
 		r = newIntCmd(c.Do(ctx, c.builder.StrLenCompleted(key)))
@@ -4,10 +4,15 @@ import (
 	"context"
 	"github.com/prometheus/client_golang/prometheus"
 	"sync"
+	"time"
 )
 
 const (
 	namespace = "redis"
+
+	// 延迟队列指标的 type 标签，区分同名的 DelayQueue 与 StreamDelayQueue
+	delayQueueTypeDelay  = "delay"
+	delayQueueTypeStream = "stream"
 )
 
 type RegisterCollectorFunc func(prometheus.Collector)
@@ -28,14 +33,21 @@ var col = &collector{
 	delayLengthDesc: prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "delay", "queue_length"),
 		"length of delay queue.",
-		[]string{"queue"},
+		[]string{"queue", "type"},
+		prometheus.Labels{},
+	),
+	delayOldestDueAgeDesc: prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "delay", "oldest_due_age_seconds"),
+		"age of the oldest due item that has not been fetched by the delay queue.",
+		[]string{"queue", "type"},
 		prometheus.Labels{},
 	),
 }
 
 type collector struct {
-	cs              sync.Map
-	delayLengthDesc *prometheus.Desc
+	cs                    sync.Map
+	delayLengthDesc       *prometheus.Desc
+	delayOldestDueAgeDesc *prometheus.Desc
 }
 
 func registerCollector(rc RegisterCollectorFunc, c *client) {
@@ -47,6 +59,7 @@ func registerCollector(rc RegisterCollectorFunc, c *client) {
 
 func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.delayLengthDesc
+	ch <- c.delayOldestDueAgeDesc
 }
 
 func (c *collector) Collect(ch chan<- prometheus.Metric) {
@@ -56,15 +69,34 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			return true
 		}
 		cli.delayQueues.Range(func(key, value any) bool {
-			l, _ := value.(*delayQueue).Length(context.Background())
-			ch <- prometheus.MustNewConstMetric(
-				c.delayLengthDesc,
-				prometheus.GaugeValue,
-				float64(l),
-				key.(string),
-			)
+			q := value.(*delayQueue)
+			l, _ := q.Length(context.Background())
+			age, _ := oldestDueAge(context.Background(), cli, q.pollKeys[0])
+			c.collectDelay(ch, key.(string), delayQueueTypeDelay, l, age)
+			return true
+		})
+		cli.streamDelayQueues.Range(func(key, value any) bool {
+			q := value.(*streamDelayQueue)
+			l, _ := q.Length(context.Background())
+			age, _ := oldestDueAge(context.Background(), cli, q.timerKey)
+			c.collectDelay(ch, key.(string), delayQueueTypeStream, l, age)
 			return true
 		})
 		return true
 	})
 }
+
+func (c *collector) collectDelay(ch chan<- prometheus.Metric, name, typ string, length int64, age time.Duration) {
+	ch <- prometheus.MustNewConstMetric(
+		c.delayLengthDesc,
+		prometheus.GaugeValue,
+		float64(length),
+		name, typ,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.delayOldestDueAgeDesc,
+		prometheus.GaugeValue,
+		age.Seconds(),
+		name, typ,
+	)
+}
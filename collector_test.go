@@ -0,0 +1,41 @@
+package redisson
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCollector(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	if !c.Options().GetDevelopment() {
+		c.FlushAll(context.Background())
+	}
+
+	Convey("delay queue with same name", t, func() {
+		name := "mock_collector"
+		dq, err := c.NewDelayQueue(name, func([]byte) error { return nil })
+		So(err, ShouldBeNil)
+		sq, err := c.NewStreamDelayQueue(name, func(*DelayMessage) error { return nil })
+		So(err, ShouldBeNil)
+
+		cl := &collector{delayLengthDesc: col.delayLengthDesc, delayOldestDueAgeDesc: col.delayOldestDueAgeDesc}
+		cl.cs.Store(c.(*client), struct{}{})
+		reg := prometheus.NewPedanticRegistry()
+		So(reg.Register(cl), ShouldBeNil)
+		mfs, err := reg.Gather()
+		So(err, ShouldBeNil)
+		So(len(mfs), ShouldEqual, 2)
+		for _, mf := range mfs {
+			So(len(mf.GetMetric()), ShouldEqual, 2)
+		}
+
+		So(dq.Close(), ShouldBeNil)
+		So(sq.Close(), ShouldBeNil)
+	})
+}
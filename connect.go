@@ -71,8 +71,13 @@ func (c *client) revise(ctx context.Context) error {
 	return nil
 }
 
-func confVisitor2ClientOption(v ConfVisitor) rueidis.ClientOption {
+func confVisitor2ClientOption(v ConfVisitor) (rueidis.ClientOption, error) {
+	tlsConfig, loader, err := newTLSConfig(v)
+	if err != nil {
+		return rueidis.ClientOption{}, err
+	}
 	opt := rueidis.ClientOption{
+		TLSConfig:         tlsConfig,
 		Username:          v.GetUsername(),
 		Password:          v.GetPassword(),
 		InitAddress:       v.GetAddrs(),
@@ -90,6 +95,7 @@ func confVisitor2ClientOption(v ConfVisitor) rueidis.ClientOption {
 			Password:   v.GetPassword(),
 			ClientName: v.GetName(),
 			MasterSet:  v.GetMasterName(),
+			TLSConfig:  tlsConfig,
 		},
 	}
 	switch strings.ToLower(v.GetNet()) {
@@ -97,16 +103,23 @@ func confVisitor2ClientOption(v ConfVisitor) rueidis.ClientOption {
 		opt.DialFn = func(s string, dialer *net.Dialer, _ *tls.Config) (net.Conn, error) {
 			return dialer.Dial("unix", s)
 		}
+	default:
+		if loader != nil {
+			opt.DialFn = loader.dial
+		}
 	}
-	return opt
+	return opt, nil
 }
 
 func (c *client) connect() error {
 	if t := c.v.GetT(); t != nil {
 		_ = c.v.ApplyOption(WithAddrs(miniredis.RunT(t).Addr()))
 	}
-	var err error
-	c.cmd, err = rueidis.NewClient(confVisitor2ClientOption(c.v))
+	opt, err := confVisitor2ClientOption(c.v)
+	if err != nil {
+		return err
+	}
+	c.cmd, err = rueidis.NewClient(opt)
 	if err != nil {
 		return err
 	}
@@ -152,7 +165,7 @@ func (c *client) reconnectWhenError(err error) error {
 	errString := err.Error()
 	for _, f := range reconnectErrors {
 		if ok := f(c, errString); ok {
-			warning(fmt.Sprintf("%s, reconnect...", errString))
+			warning(c.v, "reconnect", errorField(err))
 			_ = c.Close()
 			return c.connect()
 		}
@@ -166,6 +179,16 @@ func (c *client) Close() error {
 		return true
 	})
 	c.delayQueues = sync.Map{}
+	c.streamDelayQueues.Range(func(key, value any) bool {
+		_ = value.(*streamDelayQueue).Close()
+		return true
+	})
+	c.streamDelayQueues = sync.Map{}
+	c.schedulers.Range(func(key, value any) bool {
+		_ = value.(*scheduler).Close()
+		return true
+	})
+	c.schedulers = sync.Map{}
 	if c.cmd != nil {
 		c.cmd.Close()
 	}
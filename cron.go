@@ -0,0 +1,249 @@
+package redisson
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCronSpec = errors.New("invalid cron spec")
+
+// Schedule 调度计划，返回给定时间之后的下一次执行时间
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// everySchedule 固定间隔的调度计划
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronSchedule 标准 cron 调度计划，每个字段使用位图表示
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	loc                                   *time.Location
+}
+
+type cronBounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronSeconds = cronBounds{min: 0, max: 59}
+	cronMinutes = cronBounds{min: 0, max: 59}
+	cronHours   = cronBounds{min: 0, max: 23}
+	cronDom     = cronBounds{min: 1, max: 31}
+	cronMonths  = cronBounds{min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = cronBounds{min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// starBit 字段为 * 或 ? 时设置，用于处理 day-of-month 与 day-of-week 的组合语义
+const starBit = 1 << 63
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCron 解析 cron 表达式，支持以下格式：
+//   - 标准 5 段格式：分 时 日 月 周
+//   - 6 段格式，第一段为秒
+//   - @yearly、@monthly、@weekly、@daily、@hourly 等描述符
+//   - @every <duration>，如 @every 1m30s
+//
+// loc 为 nil 时使用 time.Local
+func ParseCron(spec string, loc *time.Location) (Schedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCronSpec, spec)
+		}
+		return everySchedule{interval: d}, nil
+	}
+	if d, ok := cronDescriptors[spec]; ok {
+		spec = d
+	}
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: %q, expected 5 or 6 fields", ErrInvalidCronSpec, spec)
+	}
+	s := &cronSchedule{loc: loc}
+	for i, p := range []struct {
+		dst *uint64
+		b   cronBounds
+	}{
+		{&s.second, cronSeconds},
+		{&s.minute, cronMinutes},
+		{&s.hour, cronHours},
+		{&s.dom, cronDom},
+		{&s.month, cronMonths},
+		{&s.dow, cronDow},
+	} {
+		v, err := parseCronField(fields[i], p.b)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q, %s", ErrInvalidCronSpec, spec, err.Error())
+		}
+		*p.dst = v
+	}
+	// 周日可以使用 0 或 7 表示
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+	return s, nil
+}
+
+func parseCronField(field string, b cronBounds) (uint64, error) {
+	var res uint64
+	for _, expr := range strings.Split(field, ",") {
+		v, err := parseCronRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		res |= v
+	}
+	return res, nil
+}
+
+func parseCronRange(expr string, b cronBounds) (uint64, error) {
+	var (
+		start, end, step uint = 0, 0, 1
+		extra            uint64
+		err              error
+	)
+	rangeAndStep := strings.Split(expr, "/")
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	if len(rangeAndStep) > 2 || len(lowAndHigh) > 2 {
+		return 0, fmt.Errorf("invalid expression %q", expr)
+	}
+	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+		if len(lowAndHigh) != 1 {
+			return 0, fmt.Errorf("invalid expression %q", expr)
+		}
+		start, end = b.min, b.max
+		extra = starBit
+	} else {
+		if start, err = parseCronValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = parseCronValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if len(rangeAndStep) == 2 {
+		var n uint64
+		if n, err = strconv.ParseUint(rangeAndStep[1], 10, 32); err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid step %q", rangeAndStep[1])
+		}
+		step = uint(n)
+		// N/step 表示从 N 开始至最大值
+		if len(lowAndHigh) == 1 && extra == 0 {
+			end = b.max
+		}
+		if step > 1 {
+			extra = 0
+		}
+	}
+	if start < b.min || end > b.max || start > end {
+		return 0, fmt.Errorf("value out of range %q", expr)
+	}
+	var res uint64
+	for i := start; i <= end; i += step {
+		res |= 1 << i
+	}
+	return res | extra, nil
+}
+
+func parseCronValue(v string, b cronBounds) (uint, error) {
+	if b.names != nil {
+		if n, ok := b.names[strings.ToLower(v)]; ok {
+			return n, nil
+		}
+	}
+	n, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", v)
+	}
+	return uint(n), nil
+}
+
+// Next 返回 t 之后的下一次执行时间，5 年内无匹配时返回零值
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	origLoc := t.Location()
+	t = t.In(s.loc)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + 5
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for 1<<uint(t.Month())&s.month == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+	for 1<<uint(t.Hour())&s.hour == 0 {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+	for 1<<uint(t.Minute())&s.minute == 0 {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+	for 1<<uint(t.Second())&s.second == 0 {
+		t = t.Truncate(time.Second).Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+	return t.In(origLoc)
+}
+
+// dayMatches day-of-month 与 day-of-week 均有限制时，满足其一即可
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.dow > 0
+	if s.dom&starBit > 0 || s.dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
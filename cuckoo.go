@@ -0,0 +1,319 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/redis/rueidis/rueidisprob"
+	"github.com/twmb/murmur3"
+)
+
+// 布谷鸟过滤器的每个桶有 bucket_size 个 16 位的指纹，0 表示空位
+// 指纹 fp 的两个候选桶满足 i2 = (hash(fp) - i1) mod n，因此可以在脚本中由任意一个桶计算出另一个桶
+var cuckooFilterFuncLua = `
+local filter, counter = KEYS[1], KEYS[2]
+local bucket_size, buckets = tonumber(ARGV[1]), tonumber(ARGV[2])
+local function offset(i, j)
+	return (i * bucket_size + j) * 16
+end
+local function get(i, j)
+	return redis.call('BITFIELD', filter, 'GET', 'u16', offset(i, j))[1]
+end
+local function set(i, j, fp)
+	redis.call('BITFIELD', filter, 'SET', 'u16', offset(i, j), fp)
+end
+local function alt(i, fp)
+	return (fp * 1540483477 % buckets - i) % buckets
+end
+local function find(i, fp)
+	for j = 0, bucket_size - 1 do
+		if get(i, j) == fp then
+			return j
+		end
+	end
+	return -1
+end
+`
+
+var cuckooAddLua = cuckooFilterFuncLua + `
+local max_kicks = tonumber(ARGV[3])
+local function insert(i, fp)
+	local j = find(i, 0)
+	if j < 0 then
+		return false
+	end
+	set(i, j, fp)
+	return true
+end
+local function add(fp, i1, i2)
+	if insert(i1, fp) or insert(i2, fp) then
+		return true
+	end
+	-- 依次踢出已有的指纹，失败时按相反的顺序恢复
+	local i, path = i1, {}
+	for kick = 1, max_kicks do
+		local j = (fp + kick) % bucket_size
+		local victim = get(i, j)
+		set(i, j, fp)
+		table.insert(path, {i, j, victim})
+		fp, i = victim, alt(i, victim)
+		if insert(i, fp) then
+			return true
+		end
+	end
+	for p = #path, 1, -1 do
+		set(path[p][1], path[p][2], path[p][3])
+	end
+	return false
+end
+local result, added = {}, 0
+for k = 4, #ARGV, 3 do
+	if add(tonumber(ARGV[k]), tonumber(ARGV[k + 1]), tonumber(ARGV[k + 2])) then
+		table.insert(result, 1)
+		added = added + 1
+	else
+		table.insert(result, 0)
+	end
+end
+if added > 0 then
+	redis.call('INCRBY', counter, added)
+end
+return result
+`
+
+var cuckooRemoveLua = cuckooFilterFuncLua + `
+local removed = 0
+for k = 3, #ARGV, 3 do
+	local fp = tonumber(ARGV[k])
+	for _, i in ipairs({tonumber(ARGV[k + 1]), tonumber(ARGV[k + 2])}) do
+		local j = find(i, fp)
+		if j >= 0 then
+			set(i, j, 0)
+			removed = removed + 1
+			break
+		end
+	end
+end
+if removed > 0 then
+	redis.call('DECRBY', counter, removed)
+end
+return removed
+`
+
+var cuckooExistsLua = cuckooFilterFuncLua + `
+local result = {}
+for k = 3, #ARGV, 3 do
+	local fp = tonumber(ARGV[k])
+	if find(tonumber(ARGV[k + 1]), fp) >= 0 or find(tonumber(ARGV[k + 2]), fp) >= 0 then
+		table.insert(result, 1)
+	else
+		table.insert(result, 0)
+	end
+end
+return result
+`
+
+var (
+	ErrInvalidCuckooBucketSize = errors.New("cuckoo filter bucket size must be greater than 0")
+	ErrCuckooFilterFull        = errors.New("cuckoo filter is full")
+)
+
+const (
+	cuckooFilterKeyFormat        = "cf:{%s}"
+	cuckooFilterCounterKeyFormat = "cf:{%s}:c"
+	cuckooFingerprintBits        = 16
+	// cuckooLoadFactor 桶的目标负载率
+	cuckooLoadFactor = 0.95
+)
+
+// CuckooFilter based on Redis Bitmaps with 16-bit fingerprints.
+// CuckooFilter uses 128-bit murmur3 hash function. Items can be removed, and it is more space efficient than
+// Counting Bloom Filter when the false positive rate is low.
+type CuckooFilter interface {
+	// Add adds an item to the Cuckoo Filter. It returns ErrCuckooFilterFull if there is no room for the item.
+	// NOTE: The same item can be added more than once, and should be removed as many times.
+	Add(ctx context.Context, key string) error
+
+	// AddMulti adds one or more items to the Cuckoo Filter.
+	// It returns ErrCuckooFilterFull if any item cannot be added, while the other items are still added.
+	// NOTE: If keys are too many, it can block the Redis server for a long time.
+	AddMulti(ctx context.Context, keys []string) error
+
+	// Remove removes an item from the Cuckoo Filter. It is a no-op if the item does not exist.
+	// NOTE: Removing an item which was never added may remove another item with the same fingerprint.
+	Remove(ctx context.Context, key string) error
+
+	// RemoveMulti removes one or more items from the Cuckoo Filter.
+	// NOTE: If keys are too many, it can block the Redis server for a long time.
+	RemoveMulti(ctx context.Context, keys []string) error
+
+	// Exists checks if an item is in the Cuckoo Filter.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// ExistsMulti checks if one or more items are in the Cuckoo Filter.
+	// Returns a slice of bool values where each bool indicates whether the corresponding key was found.
+	ExistsMulti(ctx context.Context, keys []string) ([]bool, error)
+
+	// Reset resets the Cuckoo Filter.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the Cuckoo Filter.
+	Delete(ctx context.Context) error
+
+	// Count returns count of items in Cuckoo Filter.
+	Count(ctx context.Context) (uint64, error)
+}
+
+type cuckooFilter struct {
+	c       *client
+	spec    CuckooOptionsVisitor
+	name    string
+	counter string
+	buckets uint64
+
+	readOnly     bool
+	addScript    Scripter
+	removeScript Scripter
+	existsScript Scripter
+}
+
+func newCuckooFilter(c *client, name string, capacity uint, opts ...CuckooOption) (CuckooFilter, error) {
+	if c.version.LessThan(mustNewSemVersion(bloomFilterROVersion)) {
+		opts = append(opts, WithCuckooOptionEnableReadOperation(false))
+	}
+	if len(name) == 0 {
+		return nil, rueidisprob.ErrEmptyName
+	}
+	spec := newCuckooOptions(opts...)
+	bucketSize := spec.GetBucketSize()
+	if bucketSize == 0 {
+		return nil, ErrInvalidCuckooBucketSize
+	}
+	buckets := uint64(math.Ceil(float64(capacity) / float64(bucketSize) / cuckooLoadFactor))
+	if buckets == 0 {
+		return nil, rueidisprob.ErrBitsSizeZero
+	}
+	if buckets*uint64(bucketSize)*cuckooFingerprintBits > 1<<32 {
+		return nil, ErrBloomFilterSizeTooLarge
+	}
+	existsLua := cuckooExistsLua
+	if spec.GetEnableReadOperation() {
+		existsLua = readOnlyBitField(cuckooExistsLua)
+	}
+	return &cuckooFilter{
+		c:            c,
+		spec:         spec,
+		name:         fmt.Sprintf(cuckooFilterKeyFormat, name),
+		counter:      fmt.Sprintf(cuckooFilterCounterKeyFormat, name),
+		buckets:      buckets,
+		readOnly:     spec.GetEnableReadOperation(),
+		addScript:    c.CreateScript(cuckooAddLua),
+		removeScript: c.CreateScript(cuckooRemoveLua),
+		existsScript: c.CreateScript(existsLua),
+	}, nil
+}
+
+// readOnlyBitField 将脚本中的 BITFIELD 替换为 BITFIELD_RO，脚本中只能有 GET 操作
+func readOnlyBitField(lua string) string {
+	return strings.ReplaceAll(lua, "'BITFIELD',", "'BITFIELD_RO',")
+}
+
+// fingerprint 返回元素的指纹以及两个候选桶
+func (f *cuckooFilter) fingerprint(key string) (fp, i1, i2 uint64) {
+	h1, h2 := murmur3.Sum128([]byte(key))
+	fp = h2 >> (64 - cuckooFingerprintBits)
+	if fp == 0 {
+		fp = 1
+	}
+	i1 = h1 % f.buckets
+	// 与脚本中的 alt 一致，fp * 1540483477 小于 2^53，在 Lua 中也是精确的
+	i2 = (fp*1540483477%f.buckets + f.buckets - i1) % f.buckets
+	return
+}
+
+// args 返回脚本参数，前两个为桶的大小以及数量，extra 之后为每个元素的指纹以及两个候选桶
+func (f *cuckooFilter) args(keys []string, extra ...any) []any {
+	args := make([]any, 0, 2+len(extra)+len(keys)*3)
+	args = append(args, f.spec.GetBucketSize(), f.buckets)
+	args = append(args, extra...)
+	for _, key := range keys {
+		fp, i1, i2 := f.fingerprint(key)
+		args = append(args, fp, i1, i2)
+	}
+	return args
+}
+
+func (f *cuckooFilter) Add(ctx context.Context, key string) error {
+	return f.AddMulti(ctx, []string{key})
+}
+
+func (f *cuckooFilter) AddMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	added, err := int64SliceToBools(f.addScript.Run(ctx, []string{f.name, f.counter}, f.args(keys, f.spec.GetMaxKicks())...).Int64Slice())
+	if err != nil {
+		return err
+	}
+	for _, ok := range added {
+		if !ok {
+			return ErrCuckooFilterFull
+		}
+	}
+	return nil
+}
+
+func (f *cuckooFilter) Remove(ctx context.Context, key string) error {
+	return f.RemoveMulti(ctx, []string{key})
+}
+
+func (f *cuckooFilter) RemoveMulti(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return f.removeScript.Run(ctx, []string{f.name, f.counter}, f.args(keys)...).Err()
+}
+
+func (f *cuckooFilter) Exists(ctx context.Context, key string) (bool, error) {
+	exists, err := f.ExistsMulti(ctx, []string{key})
+	if err != nil {
+		return false, err
+	}
+	return exists[0], nil
+}
+
+func (f *cuckooFilter) ExistsMulti(ctx context.Context, keys []string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if f.readOnly {
+		return int64SliceToBools(f.existsScript.RunRO(ctx, []string{f.name, f.counter}, f.args(keys)...).Int64Slice())
+	}
+	return int64SliceToBools(f.existsScript.Run(ctx, []string{f.name, f.counter}, f.args(keys)...).Int64Slice())
+}
+
+func (f *cuckooFilter) Reset(ctx context.Context) error {
+	if err := f.c.Set(ctx, f.name, "", 0).Err(); err != nil {
+		return err
+	}
+	return f.c.Set(ctx, f.counter, 0, 0).Err()
+}
+
+func (f *cuckooFilter) Delete(ctx context.Context) error {
+	if err := f.c.Del(ctx, f.name).Err(); err != nil {
+		return err
+	}
+	return f.c.Del(ctx, f.counter).Err()
+}
+
+func (f *cuckooFilter) Count(ctx context.Context) (uint64, error) {
+	return countOf(ctx, f.c, f.counter)
+}
+
+// NewCuckooFilter 新建一个布谷鸟过滤器，支持删除元素，相同名称的过滤器应使用相同的 capacity 以及 BucketSize
+func (c *client) NewCuckooFilter(name string, capacity uint, opts ...CuckooOption) (CuckooFilter, error) {
+	return newCuckooFilter(c, name, capacity, opts...)
+}
@@ -2,9 +2,9 @@ package redisson
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -13,8 +13,11 @@ import (
 
 var moveDelayTaskLua = `
 local source_set, target_set  = KEYS[1], KEYS[2]
-local max_priority, score = ARGV[1], ARGV[2]
-local items = redis.call('ZRANGEBYSCORE', source_set, '-inf', max_priority, 'WITHSCORES')
+local max_priority, score, limit = ARGV[1], ARGV[2], tonumber(ARGV[3] or '-1')
+if limit <= 0 then
+	limit = -1
+end
+local items = redis.call('ZRANGEBYSCORE', source_set, '-inf', max_priority, 'WITHSCORES', 'LIMIT', 0, limit)
 for i, value in ipairs(items) do
 	if i % 2 ~= 0 then
 		redis.call('ZADD', target_set, score or 0.0, value)
@@ -31,79 +34,321 @@ local l2 = redis.call('ZCARD', doing_set)
 return l1+l2
 `
 
+var pollDelayTaskLua = `
+local delay_set, doing_set, data_hash, attempts_hash, paused_key = KEYS[1], KEYS[2], KEYS[3], KEYS[4], KEYS[5]
+local max_priority, score, limit = ARGV[1], ARGV[2], tonumber(ARGV[3] or '-1')
+if redis.call('EXISTS', paused_key) == 1 then
+	return {}
+end
+if limit <= 0 then
+	limit = -1
+end
+local items = redis.call('ZRANGEBYSCORE', delay_set, '-inf', max_priority, 'WITHSCORES', 'LIMIT', 0, limit)
+local res = {}
+for i = 1, #items, 2 do
+	local id = items[i]
+	redis.call('ZADD', doing_set, score or 0.0, id)
+	redis.call('ZREM', delay_set, id)
+	table.insert(res, id)
+	table.insert(res, items[i+1])
+	table.insert(res, redis.call('HGET', data_hash, id) or '')
+	table.insert(res, redis.call('HGET', attempts_hash, id) or '')
+end
+local next = redis.call('ZRANGE', delay_set, 0, 0, 'WITHSCORES')
+table.insert(res, 1, next[2] or '')
+return res
+`
+
 var addDelayTaskLua = `
-local delay_set  = KEYS[1]
-local value, score = ARGV[1], ARGV[2]
+local delay_set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3]
+local value, score, data = ARGV[1], ARGV[2], ARGV[3]
+redis.call('HDEL', attempts_hash, value)
+if data and data ~= '' then
+	redis.call('HSET', data_hash, value, data)
+else
+	redis.call('HDEL', data_hash, value)
+end
 redis.call('ZADD', delay_set, score or 0.0, value)
 return {true}
 `
 
 var delDelayTaskLua = `
-local delay_set, doing_set  = KEYS[1], KEYS[2]
+local delay_set, doing_set, data_hash, dead_set, attempts_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4], KEYS[5]
 local value = ARGV[1]
 redis.call('ZREM', doing_set, value)
 redis.call('ZREM', delay_set, value)
+redis.call('ZREM', dead_set, value)
+redis.call('HDEL', data_hash, value)
+redis.call('HDEL', attempts_hash, value)
 return {true}
 `
 
 var consumeDelayTaskSuccessLua = `
-local doing_set  = KEYS[1]
+local doing_set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3]
 local value = ARGV[1]
 redis.call('ZREM', doing_set, value)
+redis.call('HDEL', data_hash, value)
+redis.call('HDEL', attempts_hash, value)
 return {true}
 `
 
+var deadDelayTaskLua = `
+local doing_set, dead_set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local value, score, max_len, attempts = ARGV[1], ARGV[2], tonumber(ARGV[3]), ARGV[4]
+redis.call('ZREM', doing_set, value)
+redis.call('HSET', attempts_hash, value, attempts)
+redis.call('ZADD', dead_set, score, value)
+local n = redis.call('ZCARD', dead_set)
+if max_len > 0 and n > max_len then
+	local trimmed = redis.call('ZRANGE', dead_set, 0, n-max_len-1)
+	for _, id in ipairs(trimmed) do
+		redis.call('HDEL', data_hash, id)
+		redis.call('HDEL', attempts_hash, id)
+	end
+	redis.call('ZREMRANGEBYRANK', dead_set, 0, n-max_len-1)
+end
+return {true}
+`
+
+var releaseDelayTaskLua = `
+local delay_set, doing_set = KEYS[1], KEYS[2]
+for i = 1, #ARGV, 2 do
+	if redis.call('ZREM', doing_set, ARGV[i]) == 1 then
+		redis.call('ZADD', delay_set, ARGV[i+1], ARGV[i])
+	end
+end
+return {true}
+`
+
+var getDelayTaskLua = `
+local delay_set, doing_set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local value = ARGV[1]
+local score = redis.call('ZSCORE', delay_set, value)
+if not score then
+	score = redis.call('ZSCORE', doing_set, value)
+end
+if not score then
+	return false
+end
+return {value, score, redis.call('HGET', data_hash, value) or '', redis.call('HGET', attempts_hash, value) or ''}
+`
+
+var peekDelayTaskLua = `
+local set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3]
+local count = tonumber(ARGV[1])
+local items = redis.call('ZRANGE', set, 0, count-1, 'WITHSCORES')
+local res = {}
+for i = 1, #items, 2 do
+	table.insert(res, items[i])
+	table.insert(res, items[i+1])
+	table.insert(res, redis.call('HGET', data_hash, items[i]) or '')
+	table.insert(res, redis.call('HGET', attempts_hash, items[i]) or '')
+end
+return res
+`
+
+var rescheduleDelayTaskLua = `
+local delay_set = KEYS[1]
+local value, score = ARGV[1], ARGV[2]
+if not redis.call('ZSCORE', delay_set, value) then
+	return 0
+end
+redis.call('ZADD', delay_set, score, value)
+return 1
+`
+
 var consumeDelayTaskFailedLua = `
-local delay_set, doing_set  = KEYS[1], KEYS[2]
-local value, score = ARGV[1], tonumber(ARGV[2])
+local delay_set, doing_set, attempts_hash = KEYS[1], KEYS[2], KEYS[3]
+local value, score, attempts = ARGV[1], ARGV[2], ARGV[3]
 redis.call('ZREM', doing_set, value)
-redis.call('ZADD', delay_set, score-1, value)
+redis.call('HSET', attempts_hash, value, attempts)
+redis.call('ZADD', delay_set, score, value)
 return {true}
 `
 
+var purgeDelayTaskLua = `
+local delay_set, doing_set, data_hash, attempts_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local n = 0
+for _, set in ipairs({delay_set, doing_set}) do
+	local ids = redis.call('ZRANGE', set, 0, -1)
+	for _, id in ipairs(ids) do
+		redis.call('HDEL', data_hash, id)
+		redis.call('HDEL', attempts_hash, id)
+	end
+	redis.call('DEL', set)
+	n = n + #ids
+end
+return n
+`
+
+var requeueDeadLetterLua = `
+local dead_set, delay_set, attempts_hash = KEYS[1], KEYS[2], KEYS[3]
+local count, score = tonumber(ARGV[1]), ARGV[2]
+if count <= 0 then
+	count = 0
+end
+local ids = redis.call('ZRANGE', dead_set, 0, count-1)
+for _, id in ipairs(ids) do
+	redis.call('ZREM', dead_set, id)
+	redis.call('HDEL', attempts_hash, id)
+	redis.call('ZADD', delay_set, score, id)
+end
+return #ids
+`
+
 var (
 	ErrEmptyDelayQueueName     = errors.New(" delay queue name cannot be empty")
 	ErrEmptyDelayQueueCallback = errors.New(" delay queue callback cannot be empty")
 	ErrDelayQueueHasClosed     = errors.New("delay queue has closed")
 	ErrDelayQueueHasStarted    = errors.New("delay queue has started")
+	ErrDelayTaskNotFound       = errors.New("delay task not found")
+	ErrEmptyDelayMessageID     = errors.New("delay message id cannot be empty")
+	ErrDelayQueueDrainTimeout  = errors.New("delay queue drain timeout")
 )
 
 const (
 	delayLogPrefix      = "[redis-delay]:"
 	delayKeyFormat      = "do:{%s}"
 	delayDoingKeyFormat = "doing:{%s}"
+	delayDataKeyFormat  = "data:{%s}"
+	delayDeadKeyFormat  = "dead:{%s}"
+	// delayAttemptsKeyFormat 存储任务失败的次数
+	delayAttemptsKeyFormat = "attempts:{%s}"
+	// delayPausedKeyFormat 存在时所有实例暂停取出到期任务
+	delayPausedKeyFormat = "paused:{%s}"
+
+	// redis_delay_processed 的 result 标签，failure 表示处理失败并等待重试
+	delayResultSuccess = "success"
+	delayResultFailure = "failure"
+	delayResultDead    = "dead"
 )
 
+// DelayMessage 延迟队列消息
+type DelayMessage struct {
+	// ID 消息 ID，相同的 ID 看做相同的任务
+	ID string
+	// Payload 消息内容
+	Payload []byte
+	// Headers 消息头
+	Headers map[string]string
+	// Attempts 已投递的次数，投递给业务处理时包含本次，从 1 开始
+	Attempts int
+	// DueAt 到期时间，死信为进入死信的时间，旧版本写入的待重试任务为零值
+	DueAt time.Time
+}
+
+// delayRecord 存储于 data 哈希表中的任务数据
+type delayRecord struct {
+	Payload []byte            `json:"p"`
+	Headers map[string]string `json:"h,omitempty"`
+}
+
+// delayMessageFields 脚本返回的每个任务包含的字段数：ID、分数、数据以及失败次数
+const delayMessageFields = 4
+
+// newDelayMessage 根据任务 ID、分数、data 哈希表中的数据以及失败次数生成消息
+// 通过 Add 添加的任务没有对应的数据，ID 即为 Payload
+func newDelayMessage(id, score, data, attempts string) *DelayMessage {
+	m := &DelayMessage{ID: id, Payload: []byte(id)}
+	if data != "" {
+		var r delayRecord
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			m.Payload, m.Headers = r.Payload, r.Headers
+		}
+	}
+	m.Attempts, _ = strconv.Atoi(attempts)
+	sc, _ := strconv.ParseFloat(score, 64)
+	if sc < 0 {
+		// 旧版本使用负分数记录失败次数
+		if m.Attempts == 0 {
+			m.Attempts = int(-sc)
+		}
+		return m
+	}
+	m.DueAt = time.UnixMilli(int64(sc))
+	return m
+}
+
+func newDelayMessages(res []string) []*DelayMessage {
+	ms := make([]*DelayMessage, 0, len(res)/delayMessageFields)
+	for i := 0; i+delayMessageFields <= len(res); i += delayMessageFields {
+		ms = append(ms, newDelayMessage(res[i], res[i+1], res[i+2], res[i+3]))
+	}
+	return ms
+}
+
 type DelayQueue interface {
 	// Add 添加任务
 	// bytes 具有唯一性，即相同的 bytes 看做相同的任务，相同的 bytes 会进行覆盖
 	Add(ctx context.Context, bytes []byte, seconds time.Duration) error
 	// Del 删除任务
 	Del(ctx context.Context, bytes []byte) error
+	// AddWithID 使用指定 ID 添加任务，相同的 ID 会进行覆盖，相同的 payload 可以重复添加
+	AddWithID(ctx context.Context, id string, payload []byte, delay time.Duration, headers map[string]string) error
+	// Get 获取未处理完成的任务，不存在时返回 ErrDelayTaskNotFound
+	Get(ctx context.Context, id string) (*DelayMessage, error)
+	// Reschedule 重新设置未到期任务的延迟时间，不存在时返回 ErrDelayTaskNotFound
+	Reschedule(ctx context.Context, id string, delay time.Duration) error
+	// Peek 按照到期时间返回最早的 n 个未到期任务
+	Peek(ctx context.Context, n int64) ([]*DelayMessage, error)
+	// ListDeadLetters 返回最早的 n 条死信
+	ListDeadLetters(ctx context.Context, n int64) ([]*DelayMessage, error)
+	// ListInflight 返回最早的 n 个正在处理的任务，DueAt 为处理超时的时间
+	ListInflight(ctx context.Context, n int64) ([]*DelayMessage, error)
+	// RequeueDeadLetters 将最早的 n 条死信重新放入队列并立即处理，失败次数清零，n <= 0 时处理全部死信
+	// 返回重新放入队列的数量
+	RequeueDeadLetters(ctx context.Context, n int64) (int64, error)
+
+	// Pause 暂停所有实例取出到期任务，已取出的任务会继续处理
+	Pause(ctx context.Context) error
+	// Resume 恢复所有实例取出到期任务
+	Resume(ctx context.Context) error
+	// IsPaused 队列是否已暂停
+	IsPaused(ctx context.Context) (bool, error)
+	// Purge 清空未到期以及正在处理的任务，不包括死信，返回清空的任务数量
+	Purge(ctx context.Context) (int64, error)
 
 	// Length 队列长度
 	Length(ctx context.Context) (int64, error)
-	// Close 关闭队列
+	// Close 关闭队列，等待已取出的任务处理完成，最长等待 DrainTimeout
 	Close() error
+	// Shutdown 关闭队列，等待已取出的任务处理完成直到 ctx 结束，未处理完成时返回 ctx 的错误
+	// 在回调中关闭队列时需要传入已结束的 ctx，此时不等待任何任务，包括当前的任务
+	Shutdown(ctx context.Context) error
 }
 
 type delayQueue struct {
 	c       *client
 	spec    DelayOptionsVisitor
 	wg      sync.WaitGroup
+	workers sync.WaitGroup
 	exitC   chan struct{}
+	wakeC   chan struct{}
+	taskC   chan delayTask
 	name    string
 	running atomic.Bool
 
 	pollKeys    []string
 	reclaimKeys []string
+	dataKey     string
+	deadKey     string
+	attemptsKey string
+	pausedKey   string
 
+	pollScript           Scripter
 	moveScript           Scripter
 	addScript            Scripter
 	delScript            Scripter
 	lengthScript         Scripter
 	consumeSuccessScript Scripter
 	consumeFailedScript  Scripter
+	deadScript           Scripter
+	releaseScript        Scripter
+	getScript            Scripter
+	peekScript           Scripter
+	rescheduleScript     Scripter
+	purgeScript          Scripter
+	requeueScript        Scripter
 
 	callback func([]byte) error
 }
@@ -112,31 +357,49 @@ func newDelayQueue(c *client, name string, f func([]byte) error, opts ...DelayOp
 	if name == "" {
 		return nil, ErrEmptyDelayQueueName
 	}
-	if f == nil {
+	spec := newDelayOptions(opts...)
+	if f == nil && spec.GetHandleMessage() == nil {
 		return nil, ErrEmptyDelayQueueCallback
 	}
-	spec := newDelayOptions(opts...)
 	q := &delayQueue{
 		c:                    c,
 		spec:                 spec,
 		name:                 name,
+		pollScript:           c.CreateScript(pollDelayTaskLua),
 		moveScript:           c.CreateScript(moveDelayTaskLua),
 		addScript:            c.CreateScript(addDelayTaskLua),
 		delScript:            c.CreateScript(delDelayTaskLua),
 		lengthScript:         c.CreateScript(delayTaskLengthLua),
 		consumeSuccessScript: c.CreateScript(consumeDelayTaskSuccessLua),
 		consumeFailedScript:  c.CreateScript(consumeDelayTaskFailedLua),
+		deadScript:           c.CreateScript(deadDelayTaskLua),
+		releaseScript:        c.CreateScript(releaseDelayTaskLua),
+		getScript:            c.CreateScript(getDelayTaskLua),
+		peekScript:           c.CreateScript(peekDelayTaskLua),
+		rescheduleScript:     c.CreateScript(rescheduleDelayTaskLua),
+		purgeScript:          c.CreateScript(purgeDelayTaskLua),
+		requeueScript:        c.CreateScript(requeueDeadLetterLua),
 		callback:             f,
+		wakeC:                make(chan struct{}, 1),
 	}
 	delayKey := fmt.Sprintf(delayKeyFormat, name)
 	doingKey := fmt.Sprintf(delayDoingKeyFormat, name)
+	dataKey := fmt.Sprintf(delayDataKeyFormat, name)
+	deadKey := fmt.Sprintf(delayDeadKeyFormat, name)
+	attemptsKey := fmt.Sprintf(delayAttemptsKeyFormat, name)
+	pausedKey := fmt.Sprintf(delayPausedKeyFormat, name)
 	if prefix := spec.GetPrefix(); prefix != "" {
 		delayKey = fmt.Sprintf("%s:%s", prefix, delayKey)
 		doingKey = fmt.Sprintf("%s:%s", prefix, doingKey)
+		dataKey = fmt.Sprintf("%s:%s", prefix, dataKey)
+		deadKey = fmt.Sprintf("%s:%s", prefix, deadKey)
+		attemptsKey = fmt.Sprintf("%s:%s", prefix, attemptsKey)
+		pausedKey = fmt.Sprintf("%s:%s", prefix, pausedKey)
 	}
 	q.pollKeys = []string{delayKey, doingKey}
+	q.dataKey, q.deadKey, q.attemptsKey, q.pausedKey = dataKey, deadKey, attemptsKey, pausedKey
 	q.reclaimKeys = []string{doingKey, delayKey}
-	err := q.run(ticker{d: time.Second, f: q.poll}, ticker{d: time.Second, f: q.reclaim})
+	err := q.run(ticker{d: spec.GetPollInterval(), f: q.reclaim})
 	if err != nil {
 		return nil, err
 	}
@@ -144,35 +407,164 @@ func newDelayQueue(c *client, name string, f func([]byte) error, opts ...DelayOp
 }
 
 func (q *delayQueue) Add(ctx context.Context, bytes []byte, seconds time.Duration) error {
-	sec := formatSec(seconds)
-	now := nowFunc().Unix()
-	return q.addScript.Run(ctx, q.pollKeys, bytes, now+sec).Err()
+	err := q.addScript.Run(ctx, []string{q.pollKeys[0], q.dataKey, q.attemptsKey}, bytes, dueScore(seconds), "").Err()
+	if err == nil {
+		q.wakeup()
+	}
+	return err
+}
+
+func (q *delayQueue) AddWithID(ctx context.Context, id string, payload []byte, delay time.Duration, headers map[string]string) error {
+	if id == "" {
+		return ErrEmptyDelayMessageID
+	}
+	data, err := json.Marshal(delayRecord{Payload: payload, Headers: headers})
+	if err != nil {
+		return err
+	}
+	err = q.addScript.Run(ctx, []string{q.pollKeys[0], q.dataKey, q.attemptsKey}, id, dueScore(delay), data).Err()
+	if err == nil {
+		q.wakeup()
+	}
+	return err
 }
 
 func (q *delayQueue) Del(ctx context.Context, bytes []byte) error {
-	return q.delScript.Run(ctx, q.pollKeys, bytes).Err()
+	return q.delScript.Run(ctx, []string{q.pollKeys[0], q.pollKeys[1], q.dataKey, q.deadKey, q.attemptsKey}, bytes).Err()
+}
+
+func (q *delayQueue) Get(ctx context.Context, id string) (*DelayMessage, error) {
+	res, err := q.getScript.Run(ctx, []string{q.pollKeys[0], q.pollKeys[1], q.dataKey, q.attemptsKey}, id).StringSlice()
+	if err != nil {
+		if IsNil(err) {
+			err = ErrDelayTaskNotFound
+		}
+		return nil, err
+	}
+	ms := newDelayMessages(res)
+	if len(ms) == 0 {
+		return nil, ErrDelayTaskNotFound
+	}
+	return ms[0], nil
+}
+
+func (q *delayQueue) Reschedule(ctx context.Context, id string, delay time.Duration) error {
+	n, err := q.rescheduleScript.Run(ctx, q.pollKeys[:1], id, dueScore(delay)).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrDelayTaskNotFound
+	}
+	q.wakeup()
+	return nil
+}
+
+func (q *delayQueue) peek(ctx context.Context, key string, n int64) ([]*DelayMessage, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	res, err := q.peekScript.Run(ctx, []string{key, q.dataKey, q.attemptsKey}, n).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	return newDelayMessages(res), nil
+}
+
+func (q *delayQueue) Peek(ctx context.Context, n int64) ([]*DelayMessage, error) {
+	return q.peek(ctx, q.pollKeys[0], n)
+}
+
+func (q *delayQueue) ListDeadLetters(ctx context.Context, n int64) ([]*DelayMessage, error) {
+	return q.peek(ctx, q.deadKey, n)
+}
+
+func (q *delayQueue) ListInflight(ctx context.Context, n int64) ([]*DelayMessage, error) {
+	return q.peek(ctx, q.pollKeys[1], n)
+}
+
+func (q *delayQueue) RequeueDeadLetters(ctx context.Context, n int64) (int64, error) {
+	count, err := q.requeueScript.Run(ctx, []string{q.deadKey, q.pollKeys[0], q.attemptsKey}, n, dueScore(0)).Int64()
+	if err == nil && count > 0 {
+		q.wakeup()
+	}
+	return count, err
+}
+
+func (q *delayQueue) Pause(ctx context.Context) error {
+	return q.c.Set(ctx, q.pausedKey, nowFunc().UnixMilli(), 0).Err()
+}
+
+func (q *delayQueue) Resume(ctx context.Context) error {
+	err := q.c.Del(ctx, q.pausedKey).Err()
+	if err == nil {
+		q.wakeup()
+	}
+	return err
+}
+
+func (q *delayQueue) IsPaused(ctx context.Context) (bool, error) {
+	n, err := q.c.Exists(ctx, q.pausedKey).Result()
+	return n > 0, err
+}
+
+func (q *delayQueue) Purge(ctx context.Context) (int64, error) {
+	return q.purgeScript.Run(ctx, []string{q.pollKeys[0], q.pollKeys[1], q.dataKey, q.attemptsKey}).Int64()
 }
 
 func (q *delayQueue) Length(ctx context.Context) (int64, error) {
 	return q.lengthScript.Run(ctx, q.pollKeys).Int64()
 }
 
-func (q *delayQueue) move(ctx context.Context, from, to string, offset float64) ([]any, error) {
-	now := nowFunc().Unix()
-	return q.moveScript.Run(ctx, []string{from, to}, now, float64(now)+offset).Slice()
+// oldestDueAge 返回最早到期且未被取出的任务已经到期的时长，没有到期任务时返回 0
+func oldestDueAge(ctx context.Context, c *client, key string) (time.Duration, error) {
+	zs, err := c.ZRangeWithScores(ctx, key, 0, 0).Result()
+	if err != nil || len(zs) == 0 || zs[0].Score <= 0 {
+		return 0, err
+	}
+	if age := nowFunc().Sub(time.UnixMilli(int64(zs[0].Score))); age > 0 {
+		return age, nil
+	}
+	return 0, nil
 }
 
 func (q *delayQueue) isRunning() bool { return q.running.Load() }
 
+// Close 关闭队列，停止轮询后等待已取出的任务处理完成，最长等待 DrainTimeout
+// 超时未处理完成的任务，会在 Timeout 后被重新处理
 func (q *delayQueue) Close() error {
-	if !q.isRunning() {
+	ctx, cancel := context.WithTimeout(context.Background(), q.spec.GetDrainTimeout())
+	defer cancel()
+	err := q.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		warning(q.c.v, delayLogPrefix+" drain timeout", queueField(q.name))
+		return ErrDelayQueueDrainTimeout
+	}
+	return err
+}
+
+func (q *delayQueue) Shutdown(ctx context.Context) error {
+	if !q.running.CompareAndSwap(true, false) {
 		return ErrDelayQueueHasClosed
 	}
-	q.running.Store(false)
 	close(q.exitC)
 	q.wg.Wait()
 	q.c.delayQueues.Delete(q.name)
-	return nil
+	close(q.taskC)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	done := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 type ticker struct {
@@ -185,8 +577,9 @@ func (q *delayQueue) run(ts ...ticker) error {
 		return ErrDelayQueueHasStarted
 	}
 	q.running.Store(true)
-	q.wg.Add(len(ts))
+	q.wg.Add(len(ts) + 1)
 	q.exitC = make(chan struct{})
+	q.taskC = make(chan delayTask, q.spec.GetPrefetch())
 	var doTicker = func(ti ticker) {
 		t := time.NewTimer(0)
 		defer func() {
@@ -196,13 +589,11 @@ func (q *delayQueue) run(ts ...ticker) error {
 		for {
 			select {
 			case <-t.C:
+				if err := ti.f(); err != nil {
+					// 输出日志
+					e(q.c.v, delayLogPrefix+" ticker error", queueField(q.name), errorField(err))
+				}
 				_ = t.Reset(ti.d)
-				go func() {
-					if err := ti.f(); err != nil {
-						// 输出日志
-						e(fmt.Sprintf("%s ticker error, %v", delayLogPrefix, err))
-					}
-				}()
 			case <-q.exitC:
 				return
 			}
@@ -213,68 +604,209 @@ func (q *delayQueue) run(ts ...ticker) error {
 			doTicker(_ti)
 		}(ti)
 	}
+	go q.pollLoop()
+	workers := q.spec.GetWorkers()
+	if workers <= 0 {
+		workers = 1
+	}
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
 	return nil
 }
 
-func (q *delayQueue) poll() error {
+// dueScore 返回任务到期时间对应的分数，精确到毫秒
+// 旧版本写入的秒级分数会被视为已经到期
+func dueScore(delay time.Duration) int64 {
+	return nowFunc().Add(delay).UnixMilli()
+}
+
+// wakeup 唤醒轮询协程，以便新添加的任务能够及时被处理
+func (q *delayQueue) wakeup() {
+	select {
+	case q.wakeC <- struct{}{}:
+	default:
+	}
+}
+
+// pollLoop 处理到期任务，并休眠至下一个任务的到期时间，最长休眠 PollInterval
+func (q *delayQueue) pollLoop() {
+	t := time.NewTimer(0)
+	defer func() {
+		_ = t.Stop()
+		q.wg.Done()
+	}()
+	for {
+		select {
+		case <-t.C:
+		case <-q.wakeC:
+			if !t.Stop() {
+				select {
+				case <-t.C:
+				default:
+				}
+			}
+		case <-q.exitC:
+			return
+		}
+		d, err := q.poll()
+		if err != nil {
+			e(q.c.v, delayLogPrefix+" poll error", queueField(q.name), errorField(err))
+		}
+		_ = t.Reset(d)
+	}
+}
+
+// delayTask 已取出待处理的任务
+type delayTask struct {
+	msg *DelayMessage
+}
+
+func (q *delayQueue) poll() (time.Duration, error) {
 	now := nowFunc()
-	res, err := q.moveScript.Run(context.Background(), q.pollKeys, now.Unix(), float64(now.Add(q.spec.GetTimeout()).Unix())).Slice()
+	interval := q.spec.GetPollInterval()
+	batchSize := q.spec.GetBatchSize()
+	res, err := q.pollScript.Run(context.Background(), []string{q.pollKeys[0], q.pollKeys[1], q.dataKey, q.attemptsKey, q.pausedKey}, now.UnixMilli(), now.Add(q.spec.GetTimeout()).UnixMilli(), batchSize).StringSlice()
 	if err != nil {
 		q.c.handler.delayPollError(q.name)
-		return err
+		return interval, err
 	}
-	for i := 0; i < len(res); i += 2 {
-		data := []byte(res[i].(string))
-		score, _ := strconv.ParseInt(res[i+1].(string), 10, 64)
-		if score < 0 {
-			// 曾经失败过
-			if int(math.Abs(float64(score))) > q.spec.GetRetryTimes() {
-				// 死信
-				q.handleDeadLetter(data)
-				continue
-			}
-		} else {
-			score = 0
+	if len(res) == 0 {
+		return interval, nil
+	}
+	var count int64
+	for i := 1; i+delayMessageFields <= len(res); i += delayMessageFields {
+		select {
+		case q.taskC <- delayTask{msg: newDelayMessage(res[i], res[i+1], res[i+2], res[i+3])}:
+			count++
+		case <-q.exitC:
+			// 队列关闭，归还未处理的任务
+			q.release(res[i:])
+			return interval, nil
 		}
-		if err0 := q.handle(data); err0 != nil {
-			// 处理失败
-			_ = q.retryAdd(data, float64(score))
-		} else {
-			// 处理成功
-			_ = q.ackOK(data)
+	}
+	if batchSize > 0 && count >= batchSize {
+		// 可能还有到期任务
+		return 0, nil
+	}
+	if res[0] == "" {
+		return interval, nil
+	}
+	next, _ := strconv.ParseInt(res[0], 10, 64)
+	if d := time.UnixMilli(next).Sub(nowFunc()); d < interval {
+		if d < 0 {
+			d = 0
 		}
+		return d, nil
 	}
-	return nil
+	return interval, nil
+}
+
+// release 将已取出但未处理的任务放回延迟队列
+func (q *delayQueue) release(res []string) {
+	args := make([]any, 0, len(res)/delayMessageFields*2)
+	for i := 0; i+delayMessageFields <= len(res); i += delayMessageFields {
+		args = append(args, res[i], res[i+1])
+	}
+	if len(args) == 0 {
+		return
+	}
+	if err := q.releaseScript.Run(context.Background(), q.pollKeys, args...).Err(); err != nil {
+		e(q.c.v, delayLogPrefix+" release failed", queueField(q.name), errorField(err))
+	}
+}
+
+func (q *delayQueue) work() {
+	defer q.workers.Done()
+	for task := range q.taskC {
+		q.c.handler.delayInflight(q.name, 1)
+		q.process(task)
+		q.c.handler.delayInflight(q.name, -1)
+	}
+}
+
+func (q *delayQueue) process(task delayTask) {
+	m := task.msg
+	// 本次投递
+	m.Attempts++
+	start := nowFunc()
+	if !m.DueAt.IsZero() {
+		q.c.handler.delayLag(q.name, start.Sub(m.DueAt))
+	}
+	err := q.handle(m)
+	q.c.handler.delayDuration(q.name, nowFunc().Sub(start))
+	if err == nil {
+		// 处理成功
+		_ = q.ackOK(m.ID)
+		q.c.handler.delayProcessed(q.name, delayResultSuccess)
+		return
+	}
+	delay, ok := q.retryDelay(m.Attempts, err)
+	if !ok {
+		// 死信
+		q.handleDeadLetter(m.ID, m.Attempts, m.Payload)
+		q.c.handler.delayProcessed(q.name, delayResultDead)
+		return
+	}
+	// 处理失败
+	_ = q.retryAdd(m.ID, m.Attempts, delay)
+	q.c.handler.delayProcessed(q.name, delayResultFailure)
+}
+
+// retryDelay 根据业务返回的错误以及重试策略，计算下次重试的延迟时间，返回 false 表示不再重试
+func (q *delayQueue) retryDelay(attempts int, err error) (time.Duration, bool) {
+	if errors.Is(err, ErrNoRetry) || attempts > q.spec.GetRetryTimes() {
+		return 0, false
+	}
+	var ra *retryAfterError
+	if errors.As(err, &ra) {
+		return ra.delay, true
+	}
+	if policy := q.spec.GetRetryPolicy(); policy != nil {
+		return policy.Backoff(attempts), true
+	}
+	return 0, true
 }
 
-func (q *delayQueue) handle(data []byte) (err error) {
+func (q *delayQueue) handle(m *DelayMessage) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("handle task panic, %v", r)
-			e(fmt.Sprintf("%s %v", delayLogPrefix, err))
+			e(q.c.v, delayLogPrefix+" handle task panic", queueField(q.name), dataField(m.Payload), Any("panic", r))
 			return
 		}
 	}()
-	err = q.callback(data)
+	if f := q.spec.GetHandleMessage(); f != nil {
+		err = f(m)
+	} else {
+		err = q.callback(m.Payload)
+	}
 	return
 }
 
-func (q *delayQueue) handleDeadLetter(data []byte) {
-	_ = q.ackOK(data)
-	if f := q.spec.GetHandleDeadLetter(); f != nil {
-		defer func() {
-			if r := recover(); r != nil {
-				e(fmt.Sprintf("%s handle dead letter panic, %v", delayLogPrefix, r))
-				return
-			}
-		}()
-		f(data)
+func (q *delayQueue) handleDeadLetter(id string, attempts int, data []byte) {
+	err := q.deadScript.Run(context.Background(), []string{q.pollKeys[1], q.deadKey, q.dataKey, q.attemptsKey}, id, nowFunc().UnixMilli(), q.spec.GetDeadLetterMaxLen(), attempts).Err()
+	if err != nil {
+		e(q.c.v, delayLogPrefix+" dead letter failed", queueField(q.name), dataField(data), errorField(err))
+	}
+	f := q.spec.GetHandleDeadLetter()
+	if f == nil {
+		warning(q.c.v, delayLogPrefix+" got dead letter", queueField(q.name), dataField(data))
+		return
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			e(q.c.v, delayLogPrefix+" handle dead letter panic", queueField(q.name), dataField(data), Any("panic", r))
+			return
+		}
+	}()
+	f(data)
 }
 
 func (q *delayQueue) reclaim() error {
 	now := nowFunc()
-	res, err := q.moveScript.Run(context.Background(), q.reclaimKeys, now.Unix(), float64(now.Add(q.spec.GetTimeout()).Unix())).Slice()
+	res, err := q.moveScript.Run(context.Background(), q.reclaimKeys, now.UnixMilli(), now.Add(q.spec.GetTimeout()).UnixMilli(), q.spec.GetBatchSize()).Slice()
 	if err != nil {
 		q.c.handler.delayReclaimError(q.name)
 	} else if len(res) > 0 {
@@ -283,23 +815,25 @@ func (q *delayQueue) reclaim() error {
 	return err
 }
 
-func (q *delayQueue) ackOK(data []byte) error {
-	err := q.consumeSuccessScript.Run(context.Background(), q.reclaimKeys, data).Err()
+func (q *delayQueue) ackOK(id string) error {
+	err := q.consumeSuccessScript.Run(context.Background(), []string{q.pollKeys[1], q.dataKey, q.attemptsKey}, id).Err()
 	if err != nil {
-		e(fmt.Sprintf("%s ack failed, %v, %v", delayLogPrefix, data, err))
+		e(q.c.v, delayLogPrefix+" ack failed", queueField(q.name), Any("id", id), errorField(err))
 	}
 	return err
 }
 
-func (q *delayQueue) retryAdd(data []byte, score float64) error {
-	err := q.consumeFailedScript.Run(context.Background(), q.pollKeys, data, score).Err()
+func (q *delayQueue) retryAdd(id string, attempts int, delay time.Duration) error {
+	err := q.consumeFailedScript.Run(context.Background(), []string{q.pollKeys[0], q.pollKeys[1], q.attemptsKey}, id, dueScore(delay), attempts).Err()
 	if err != nil {
-		e(fmt.Sprintf("%s retry add failed, %v, %f, %v", delayLogPrefix, data, score, err))
+		e(q.c.v, delayLogPrefix+" retry add failed", queueField(q.name), Any("id", id), Any("attempts", attempts), errorField(err))
+		return err
 	}
-	return err
+	q.wakeup()
+	return nil
 }
 
-// NewDelayQueue 新建一个延迟队列
+// NewDelayQueue 新建一个延迟队列，设置 WithDelayOptionHandleMessage 时 f 可以为 nil
 func (c *client) NewDelayQueue(name string, f func([]byte) error, opts ...DelayOption) (DelayQueue, error) {
 	if val, ok := c.delayQueues.Load(name); ok {
 		return val.(*delayQueue), nil
@@ -0,0 +1,68 @@
+package redisson
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ErrNoRetry 业务处理返回该错误时，任务不再重试，直接成为死信
+var ErrNoRetry = errors.New("delay task no retry")
+
+type retryAfterError struct {
+	delay time.Duration
+}
+
+func (e *retryAfterError) Error() string { return fmt.Sprintf("delay task retry after %s", e.delay) }
+
+// ErrRetryAfter 业务处理返回该错误时，任务在 d 之后重试，忽略 RetryPolicy
+func ErrRetryAfter(d time.Duration) error { return &retryAfterError{delay: d} }
+
+// RetryPolicy 重试策略，返回第 attempts 次处理失败后的重试延迟时间，attempts 从 1 开始
+type RetryPolicy interface {
+	Backoff(attempts int) time.Duration
+}
+
+// RetryPolicyFunc 函数形式的 RetryPolicy
+type RetryPolicyFunc func(attempts int) time.Duration
+
+func (f RetryPolicyFunc) Backoff(attempts int) time.Duration { return f(attempts) }
+
+// ExponentialBackoff 指数退避，延迟时间为 base * 2^(attempts-1)，不超过 maxDelay
+// jitter 为随机抖动的比例，取值 [0, 1]，如 0.2 表示在延迟时间上下浮动 20%
+func ExponentialBackoff(base, maxDelay time.Duration, jitter float64) RetryPolicy {
+	return RetryPolicyFunc(func(attempts int) time.Duration {
+		d := base
+		for i := 1; i < attempts && i < 63 && (maxDelay <= 0 || d < maxDelay); i++ {
+			d *= 2
+		}
+		return withJitter(capDuration(d, maxDelay), jitter)
+	})
+}
+
+// LinearBackoff 线性退避，延迟时间为 step * attempts，不超过 maxDelay
+// jitter 为随机抖动的比例，取值 [0, 1]
+func LinearBackoff(step, maxDelay time.Duration, jitter float64) RetryPolicy {
+	return RetryPolicyFunc(func(attempts int) time.Duration {
+		return withJitter(capDuration(step*time.Duration(attempts), maxDelay), jitter)
+	})
+}
+
+func capDuration(d, maxDelay time.Duration) time.Duration {
+	if maxDelay > 0 && d > maxDelay {
+		return maxDelay
+	}
+	return d
+}
+
+func withJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || d <= 0 {
+		return d
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	delta := float64(d) * jitter
+	return time.Duration(float64(d) - delta + rand.Float64()*2*delta)
+}
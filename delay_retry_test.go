@@ -0,0 +1,57 @@
+package redisson
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	Convey("exponential backoff", t, func() {
+		p := ExponentialBackoff(time.Second, 5*time.Second, 0)
+		So(p.Backoff(1), ShouldEqual, time.Second)
+		So(p.Backoff(2), ShouldEqual, 2*time.Second)
+		So(p.Backoff(3), ShouldEqual, 4*time.Second)
+		So(p.Backoff(4), ShouldEqual, 5*time.Second)
+		So(p.Backoff(100), ShouldEqual, 5*time.Second)
+	})
+
+	Convey("linear backoff", t, func() {
+		p := LinearBackoff(time.Second, 3*time.Second, 0)
+		So(p.Backoff(1), ShouldEqual, time.Second)
+		So(p.Backoff(2), ShouldEqual, 2*time.Second)
+		So(p.Backoff(5), ShouldEqual, 3*time.Second)
+	})
+
+	Convey("jitter", t, func() {
+		p := ExponentialBackoff(time.Second, 0, 0.2)
+		for i := 0; i < 100; i++ {
+			d := p.Backoff(2)
+			So(d, ShouldBeGreaterThanOrEqualTo, 1600*time.Millisecond)
+			So(d, ShouldBeLessThanOrEqualTo, 2400*time.Millisecond)
+		}
+	})
+
+	Convey("retry error", t, func() {
+		var q = &delayQueue{spec: newDelayOptions(WithDelayOptionRetryTimes(2), WithDelayOptionRetryPolicy(LinearBackoff(time.Second, 0, 0)))}
+		d, ok := q.retryDelay(1, errors.New("mock error"))
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, time.Second)
+		d, ok = q.retryDelay(2, fmt.Errorf("wrap: %w", ErrRetryAfter(time.Minute)))
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, time.Minute)
+		_, ok = q.retryDelay(1, fmt.Errorf("wrap: %w", ErrNoRetry))
+		So(ok, ShouldBeFalse)
+		_, ok = q.retryDelay(3, errors.New("mock error"))
+		So(ok, ShouldBeFalse)
+
+		// 默认立即重试
+		q = &delayQueue{spec: newDelayOptions()}
+		d, ok = q.retryDelay(1, errors.New("mock error"))
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, 0)
+	})
+}
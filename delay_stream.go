@@ -0,0 +1,566 @@
+package redisson
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// version_hash 记录任务最近一次投递的 Stream 消息 ID，确认或者重试时版本不一致说明任务已被重新添加，不能删除新的数据
+var addStreamDelayTaskLua = `
+local timer_set, data_hash, attempts_hash, version_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local id, payload, score = ARGV[1], ARGV[2], ARGV[3]
+redis.call('HSET', data_hash, id, payload)
+redis.call('HDEL', attempts_hash, id)
+redis.call('HDEL', version_hash, id)
+redis.call('ZADD', timer_set, score, id)
+return {true}
+`
+
+var moveStreamDelayTaskLua = `
+local timer_set, data_hash, attempts_hash, ready_stream, version_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4], KEYS[5]
+local now, limit = ARGV[1], ARGV[2]
+local items = redis.call('ZRANGEBYSCORE', timer_set, '-inf', now, 'WITHSCORES', 'LIMIT', 0, limit)
+for i = 1, #items, 2 do
+	local id = items[i]
+	local payload = redis.call('HGET', data_hash, id)
+	if payload then
+		local attempts = tonumber(redis.call('HGET', attempts_hash, id) or '0') + 1
+		local sid = redis.call('XADD', ready_stream, '*', 'id', id, 'payload', payload, 'attempts', attempts, 'due', items[i+1])
+		redis.call('HSET', version_hash, id, sid)
+	end
+	redis.call('ZREM', timer_set, id)
+end
+return #items / 2
+`
+
+var ackStreamDelayTaskLua = `
+local ready_stream, data_hash, attempts_hash, version_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local group, sid, id = ARGV[1], ARGV[2], ARGV[3]
+redis.call('XACK', ready_stream, group, sid)
+redis.call('XDEL', ready_stream, sid)
+if redis.call('HGET', version_hash, id) == sid then
+	redis.call('HDEL', data_hash, id)
+	redis.call('HDEL', attempts_hash, id)
+	redis.call('HDEL', version_hash, id)
+end
+return {true}
+`
+
+var retryStreamDelayTaskLua = `
+local ready_stream, timer_set, data_hash, attempts_hash, dead_stream, version_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4], KEYS[5], KEYS[6]
+local group, sid, id, retry_times, score, max_len, attempts = ARGV[1], ARGV[2], ARGV[3], tonumber(ARGV[4]), ARGV[5], ARGV[6], tonumber(ARGV[7])
+redis.call('XACK', ready_stream, group, sid)
+redis.call('XDEL', ready_stream, sid)
+if redis.call('HGET', version_hash, id) ~= sid then
+	return 0
+end
+redis.call('HDEL', version_hash, id)
+local payload = redis.call('HGET', data_hash, id)
+if not payload then
+	redis.call('HDEL', attempts_hash, id)
+	return 0
+end
+if attempts > retry_times then
+	redis.call('XADD', dead_stream, 'MAXLEN', '~', max_len, '*', 'id', id, 'payload', payload, 'attempts', attempts)
+	redis.call('HDEL', data_hash, id)
+	redis.call('HDEL', attempts_hash, id)
+	return 1
+end
+redis.call('HSET', attempts_hash, id, attempts)
+redis.call('ZADD', timer_set, score, id)
+return 0
+`
+
+var delStreamDelayTaskLua = `
+local timer_set, data_hash, attempts_hash, version_hash = KEYS[1], KEYS[2], KEYS[3], KEYS[4]
+local id = ARGV[1]
+redis.call('ZREM', timer_set, id)
+redis.call('HDEL', data_hash, id)
+redis.call('HDEL', attempts_hash, id)
+redis.call('HDEL', version_hash, id)
+return {true}
+`
+
+var streamDelayTaskLengthLua = `
+local timer_set, ready_stream = KEYS[1], KEYS[2]
+local l1 = redis.call('ZCARD', timer_set)
+local l2 = 0
+if redis.call('EXISTS', ready_stream) == 1 then
+	l2 = redis.call('XLEN', ready_stream)
+end
+return l1+l2
+`
+
+const (
+	streamDelayTimerKeyFormat    = "sdq:timer:{%s}"
+	streamDelayDataKeyFormat     = "sdq:data:{%s}"
+	streamDelayAttemptsKeyFormat = "sdq:attempts:{%s}"
+	streamDelayVersionKeyFormat  = "sdq:version:{%s}"
+	streamDelayReadyKeyFormat    = "sdq:ready:{%s}"
+	streamDelayDeadKeyFormat     = "sdq:dead:{%s}"
+
+	streamDelayFieldID       = "id"
+	streamDelayFieldPayload  = "payload"
+	streamDelayFieldAttempts = "attempts"
+	streamDelayFieldDue      = "due"
+
+	// streamDelayClaimCount 每次 XAUTOCLAIM 认领的最大任务数
+	streamDelayClaimCount = 100
+)
+
+// StreamDelayQueue 基于 Redis Stream 消费组实现的延迟队列，需要 Redis 6.2 及以上版本
+// 到期的任务由有序集合移入 Stream，通过 XREADGROUP 消费，处理超时未确认的任务会被 XAUTOCLAIM 重新认领
+// 没有实现 DelayQueue：到期的任务只存在于 Stream 的待确认列表中，无法像 DelayQueue 一样查询、重新设置延迟或暂停，
+// 并且 Add 总是生成新的任务 ID，因此回调需要 *DelayMessage 获取任务 ID 以及投递次数
+type StreamDelayQueue interface {
+	// Add 添加任务，返回自动生成的任务 ID
+	Add(ctx context.Context, payload []byte, delay time.Duration) (string, error)
+	// AddWithID 使用指定 ID 添加任务，相同的 ID 会进行覆盖
+	AddWithID(ctx context.Context, id string, payload []byte, delay time.Duration) error
+	// Del 删除任务，已经投递给消费者的任务不受影响
+	Del(ctx context.Context, id string) error
+	// Length 队列长度，包含未到期以及已到期未确认的任务
+	Length(ctx context.Context) (int64, error)
+	// DeadLetters 返回最早的 count 条死信
+	DeadLetters(ctx context.Context, count int64) ([]*DelayMessage, error)
+	// Close 关闭队列
+	Close() error
+}
+
+type streamDelayQueue struct {
+	c       *client
+	spec    StreamDelayOptionsVisitor
+	name    string
+	group   string
+	member  string
+	wg      sync.WaitGroup
+	exitC   chan struct{}
+	cancel  context.CancelFunc
+	msgC    chan XMessage
+	running atomic.Bool
+	// inflight 本地已分发且尚未处理完成的 Stream 消息 ID，认领时跳过
+	inflight sync.Map
+
+	timerKey    string
+	dataKey     string
+	attemptsKey string
+	versionKey  string
+	readyKey    string
+	deadKey     string
+
+	addScript    Scripter
+	moveScript   Scripter
+	ackScript    Scripter
+	retryScript  Scripter
+	delScript    Scripter
+	lengthScript Scripter
+
+	callback func(*DelayMessage) error
+}
+
+func newStreamDelayQueue(c *client, name string, f func(*DelayMessage) error, opts ...StreamDelayOption) (*streamDelayQueue, error) {
+	if name == "" {
+		return nil, ErrEmptyDelayQueueName
+	}
+	if f == nil {
+		return nil, ErrEmptyDelayQueueCallback
+	}
+	spec := newStreamDelayOptions(opts...)
+	q := &streamDelayQueue{
+		c:            c,
+		spec:         spec,
+		name:         name,
+		group:        spec.GetGroup(),
+		member:       spec.GetConsumer(),
+		addScript:    c.CreateScript(addStreamDelayTaskLua),
+		moveScript:   c.CreateScript(moveStreamDelayTaskLua),
+		ackScript:    c.CreateScript(ackStreamDelayTaskLua),
+		retryScript:  c.CreateScript(retryStreamDelayTaskLua),
+		delScript:    c.CreateScript(delStreamDelayTaskLua),
+		lengthScript: c.CreateScript(streamDelayTaskLengthLua),
+		callback:     f,
+	}
+	if q.member == "" {
+		q.member = defaultStreamDelayConsumer()
+	}
+	var key = func(format string) string {
+		k := fmt.Sprintf(format, name)
+		if prefix := spec.GetPrefix(); prefix != "" {
+			k = fmt.Sprintf("%s:%s", prefix, k)
+		}
+		return k
+	}
+	q.timerKey = key(streamDelayTimerKeyFormat)
+	q.dataKey = key(streamDelayDataKeyFormat)
+	q.attemptsKey = key(streamDelayAttemptsKeyFormat)
+	q.versionKey = key(streamDelayVersionKeyFormat)
+	q.readyKey = key(streamDelayReadyKeyFormat)
+	q.deadKey = key(streamDelayDeadKeyFormat)
+	if err := q.createGroup(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := q.run(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func defaultStreamDelayConsumer() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), newDelayMessageID()[:8])
+}
+
+func newDelayMessageID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (q *streamDelayQueue) createGroup(ctx context.Context) error {
+	err := q.c.XGroupCreateMkStream(ctx, q.readyKey, q.group, "0").Err()
+	if err != nil && strings.Contains(err.Error(), "BUSYGROUP") {
+		err = nil
+	}
+	return err
+}
+
+func (q *streamDelayQueue) Add(ctx context.Context, payload []byte, delay time.Duration) (string, error) {
+	id := newDelayMessageID()
+	return id, q.AddWithID(ctx, id, payload, delay)
+}
+
+func (q *streamDelayQueue) AddWithID(ctx context.Context, id string, payload []byte, delay time.Duration) error {
+	if id == "" {
+		return ErrEmptyDelayMessageID
+	}
+	score := nowFunc().Add(delay).UnixMilli()
+	return q.addScript.Run(ctx, []string{q.timerKey, q.dataKey, q.attemptsKey, q.versionKey}, id, payload, score).Err()
+}
+
+func (q *streamDelayQueue) Del(ctx context.Context, id string) error {
+	return q.delScript.Run(ctx, []string{q.timerKey, q.dataKey, q.attemptsKey, q.versionKey}, id).Err()
+}
+
+func (q *streamDelayQueue) Length(ctx context.Context) (int64, error) {
+	return q.lengthScript.Run(ctx, []string{q.timerKey, q.readyKey}).Int64()
+}
+
+func (q *streamDelayQueue) DeadLetters(ctx context.Context, count int64) ([]*DelayMessage, error) {
+	res, err := q.c.XRangeN(ctx, q.deadKey, "-", "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	ms := make([]*DelayMessage, 0, len(res))
+	for _, m := range res {
+		ms = append(ms, toDelayMessage(m))
+	}
+	return ms, nil
+}
+
+func toDelayMessage(m XMessage) *DelayMessage {
+	dm := &DelayMessage{}
+	if v, ok := m.Values[streamDelayFieldID].(string); ok {
+		dm.ID = v
+	}
+	if v, ok := m.Values[streamDelayFieldPayload].(string); ok {
+		dm.Payload = []byte(v)
+	}
+	if v, ok := m.Values[streamDelayFieldAttempts].(string); ok {
+		dm.Attempts, _ = strconv.Atoi(v)
+	}
+	if v, ok := m.Values[streamDelayFieldDue].(string); ok {
+		if due, err := strconv.ParseInt(v, 10, 64); err == nil {
+			dm.DueAt = time.UnixMilli(due)
+		}
+	}
+	return dm
+}
+
+func (q *streamDelayQueue) isRunning() bool { return q.running.Load() }
+
+func (q *streamDelayQueue) Close() error {
+	if !q.isRunning() {
+		return ErrDelayQueueHasClosed
+	}
+	q.running.Store(false)
+	close(q.exitC)
+	q.cancel()
+	q.wg.Wait()
+	q.c.streamDelayQueues.Delete(q.name)
+	return nil
+}
+
+func (q *streamDelayQueue) run() error {
+	if q.isRunning() {
+		return ErrDelayQueueHasStarted
+	}
+	q.running.Store(true)
+	q.exitC = make(chan struct{})
+	q.msgC = make(chan XMessage)
+	var ctx context.Context
+	ctx, q.cancel = context.WithCancel(context.Background())
+
+	workers := q.workers()
+	claimInterval := q.spec.GetTimeout() / 2
+	if claimInterval < q.spec.GetPollInterval() {
+		claimInterval = q.spec.GetPollInterval()
+	}
+	q.wg.Add(workers + 3)
+	go q.loop(q.spec.GetPollInterval(), q.move)
+	go q.loop(claimInterval, func() error { return q.claim(ctx) })
+	go q.read(ctx)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return nil
+}
+
+func (q *streamDelayQueue) workers() int {
+	if workers := q.spec.GetWorkers(); workers > 0 {
+		return workers
+	}
+	return 1
+}
+
+func (q *streamDelayQueue) loop(d time.Duration, f func() error) {
+	t := time.NewTimer(0)
+	defer func() {
+		_ = t.Stop()
+		q.wg.Done()
+	}()
+	for {
+		select {
+		case <-t.C:
+			if err := f(); err != nil {
+				e(q.c.v, delayLogPrefix+" ticker error", queueField(q.name), errorField(err))
+			}
+			_ = t.Reset(d)
+		case <-q.exitC:
+			return
+		}
+	}
+}
+
+// move 将到期的任务移入 Stream
+func (q *streamDelayQueue) move() error {
+	err := q.moveScript.Run(context.Background(), []string{q.timerKey, q.dataKey, q.attemptsKey, q.readyKey, q.versionKey}, nowFunc().UnixMilli(), 100).Err()
+	if err != nil {
+		q.c.handler.delayPollError(q.name)
+	}
+	return err
+}
+
+// claim 认领处理超时未确认的任务，按照 XAUTOCLAIM 返回的游标遍历整个待确认列表
+func (q *streamDelayQueue) claim(ctx context.Context) error {
+	start := "0-0"
+	for q.isRunning() {
+		ms, next, err := q.c.XAutoClaim(ctx, XAutoClaimArgs{
+			Stream:   q.readyKey,
+			Group:    q.group,
+			Consumer: q.member,
+			MinIdle:  q.spec.GetTimeout(),
+			Start:    start,
+			Count:    streamDelayClaimCount,
+		}).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			q.c.handler.delayReclaimError(q.name)
+			q.recoverGroup(err)
+			return err
+		}
+		claimed := make([]XMessage, 0, len(ms))
+		for _, m := range ms {
+			// 本地正在处理的任务处理时间超过了 Timeout，不再重复分发
+			if _, loaded := q.inflight.LoadOrStore(m.ID, struct{}{}); loaded {
+				continue
+			}
+			claimed = append(claimed, q.withDeliveries(ctx, m))
+		}
+		if len(claimed) > 0 {
+			q.c.handler.delayReclaim(q.name, len(claimed))
+		}
+		q.dispatch(claimed)
+		if next == "" || next == "0-0" {
+			return nil
+		}
+		start = next
+	}
+	return nil
+}
+
+// withDeliveries 被认领的任务之前的投递未能确认，按照待确认列表中的投递次数累加 attempts
+func (q *streamDelayQueue) withDeliveries(ctx context.Context, m XMessage) XMessage {
+	res, err := q.c.XPendingExt(ctx, XPendingExtArgs{
+		Stream: q.readyKey,
+		Group:  q.group,
+		Start:  m.ID,
+		End:    m.ID,
+		Count:  1,
+	}).Result()
+	if err != nil || len(res) == 0 || res[0].RetryCount <= 1 {
+		return m
+	}
+	attempts := 1
+	if v, ok := m.Values[streamDelayFieldAttempts].(string); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			attempts = n
+		}
+	}
+	values := make(map[string]any, len(m.Values))
+	for k, v := range m.Values {
+		values[k] = v
+	}
+	values[streamDelayFieldAttempts] = strconv.Itoa(attempts + int(res[0].RetryCount) - 1)
+	return XMessage{ID: m.ID, Values: values}
+}
+
+// recoverGroup Stream 被删除后，重新创建消费组
+func (q *streamDelayQueue) recoverGroup(err error) {
+	if strings.Contains(err.Error(), "NOGROUP") {
+		_ = q.createGroup(context.Background())
+	}
+}
+
+func (q *streamDelayQueue) read(ctx context.Context) {
+	defer q.wg.Done()
+	for q.isRunning() {
+		res, err := q.c.XReadGroup(ctx, XReadGroupArgs{
+			Group:    q.group,
+			Consumer: q.member,
+			Streams:  []string{q.readyKey, ">"},
+			Count:    int64(q.workers()),
+			Block:    q.spec.GetPollInterval(),
+		}).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if !IsNil(err) {
+				q.c.handler.delayPollError(q.name)
+				e(q.c.v, delayLogPrefix+" read group error", queueField(q.name), errorField(err))
+				q.recoverGroup(err)
+				select {
+				case <-time.After(q.spec.GetPollInterval()):
+				case <-q.exitC:
+					return
+				}
+			}
+			continue
+		}
+		for _, s := range res {
+			q.dispatch(s.Messages)
+		}
+	}
+}
+
+func (q *streamDelayQueue) dispatch(ms []XMessage) {
+	for _, m := range ms {
+		q.inflight.Store(m.ID, struct{}{})
+		select {
+		case q.msgC <- m:
+		case <-q.exitC:
+			return
+		}
+	}
+}
+
+func (q *streamDelayQueue) work() {
+	defer q.wg.Done()
+	for {
+		select {
+		case m := <-q.msgC:
+			q.process(m)
+		case <-q.exitC:
+			return
+		}
+	}
+}
+
+func (q *streamDelayQueue) process(m XMessage) {
+	q.c.handler.delayInflight(q.name, 1)
+	defer func() {
+		q.inflight.Delete(m.ID)
+		q.c.handler.delayInflight(q.name, -1)
+	}()
+	dm := toDelayMessage(m)
+	// 多次投递都未能确认，不再交给业务处理，直接成为死信
+	if dm.Attempts > q.spec.GetRetryTimes()+1 {
+		if dead, _ := q.retry(m.ID, dm, dm.Attempts-1); dead {
+			q.c.handler.delayProcessed(q.name, delayResultDead)
+		}
+		return
+	}
+	start := nowFunc()
+	if !dm.DueAt.IsZero() {
+		q.c.handler.delayLag(q.name, start.Sub(dm.DueAt))
+	}
+	err := q.handle(dm)
+	q.c.handler.delayDuration(q.name, nowFunc().Sub(start))
+	if err != nil {
+		if dead, _ := q.retry(m.ID, dm, dm.Attempts); dead {
+			q.c.handler.delayProcessed(q.name, delayResultDead)
+		} else {
+			q.c.handler.delayProcessed(q.name, delayResultFailure)
+		}
+	} else {
+		_ = q.ack(m.ID, dm)
+		q.c.handler.delayProcessed(q.name, delayResultSuccess)
+	}
+}
+
+func (q *streamDelayQueue) handle(dm *DelayMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handle task panic, %v", r)
+			e(q.c.v, delayLogPrefix+" handle task panic", queueField(q.name), Any("id", dm.ID), Any("panic", r))
+			return
+		}
+	}()
+	err = q.callback(dm)
+	return
+}
+
+func (q *streamDelayQueue) ack(sid string, dm *DelayMessage) error {
+	err := q.ackScript.Run(context.Background(), []string{q.readyKey, q.dataKey, q.attemptsKey, q.versionKey}, q.group, sid, dm.ID).Err()
+	if err != nil {
+		e(q.c.v, delayLogPrefix+" ack failed", queueField(q.name), Any("id", dm.ID), errorField(err))
+	}
+	return err
+}
+
+// retry 记录失败次数 attempts，返回 true 表示达到最大重试次数，已成为死信
+func (q *streamDelayQueue) retry(sid string, dm *DelayMessage, attempts int) (bool, error) {
+	score := nowFunc().Add(q.spec.GetRetryDelay()).UnixMilli()
+	dead, err := q.retryScript.Run(context.Background(),
+		[]string{q.readyKey, q.timerKey, q.dataKey, q.attemptsKey, q.deadKey, q.versionKey},
+		q.group, sid, dm.ID, q.spec.GetRetryTimes(), score, q.spec.GetDeadLetterMaxLen(), attempts).Int64()
+	if err != nil {
+		e(q.c.v, delayLogPrefix+" retry add failed", queueField(q.name), Any("id", dm.ID), errorField(err))
+	} else if dead > 0 {
+		warning(q.c.v, delayLogPrefix+" got dead letter", queueField(q.name), Any("id", dm.ID), Any("attempts", attempts))
+	}
+	return dead > 0, err
+}
+
+// NewStreamDelayQueue 新建一个基于 Redis Stream 的延迟队列
+func (c *client) NewStreamDelayQueue(name string, f func(*DelayMessage) error, opts ...StreamDelayOption) (StreamDelayQueue, error) {
+	if val, ok := c.streamDelayQueues.Load(name); ok {
+		return val.(*streamDelayQueue), nil
+	}
+	q, err := newStreamDelayQueue(c, name, f, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.streamDelayQueues.Store(q.name, q)
+	return q, nil
+}
@@ -0,0 +1,234 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStreamDelay(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	if !c.Options().GetDevelopment() {
+		c.FlushAll(context.Background())
+	}
+	var ctx = context.Background()
+	prefix := "mock_stream_delay"
+	task := ([]byte)("task")
+
+	Convey("stream delay queue", t, func() {
+		var notifyChan = make(chan *DelayMessage, 2)
+		q, err := c.NewStreamDelayQueue("mock_normal", func(m *DelayMessage) error {
+			notifyChan <- m
+			return nil
+		}, WithStreamDelayOptionPrefix(prefix), WithStreamDelayOptionPollInterval(100*time.Millisecond))
+		So(err, ShouldBeNil)
+
+		// 相同的 payload 不会相互覆盖
+		var addTime = nowFunc()
+		id1, err := q.Add(ctx, task, time.Second)
+		So(err, ShouldBeNil)
+		id2, err := q.Add(ctx, task, time.Second)
+		So(err, ShouldBeNil)
+		So(id1, ShouldNotEqual, id2)
+
+		var l int64
+		l, err = q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(2))
+
+		var ids []string
+		for i := 0; i < 2; i++ {
+			select {
+			case m := <-notifyChan:
+				So(m.Payload, ShouldResemble, task)
+				So(m.Attempts, ShouldEqual, 1)
+				So(nowFunc().Sub(addTime), ShouldBeGreaterThanOrEqualTo, time.Second)
+				ids = append(ids, m.ID)
+			case <-time.After(5 * time.Second):
+				So("timeout", ShouldBeEmpty)
+			}
+		}
+		So(ids, ShouldContain, id1)
+		So(ids, ShouldContain, id2)
+
+		time.Sleep(200 * time.Millisecond)
+		l, err = q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(0))
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("stream delay queue del", t, func() {
+		var count atomic.Int32
+		q, err := c.NewStreamDelayQueue("mock_del", func(m *DelayMessage) error {
+			count.Add(1)
+			return nil
+		}, WithStreamDelayOptionPrefix(prefix), WithStreamDelayOptionPollInterval(100*time.Millisecond))
+		So(err, ShouldBeNil)
+
+		So(q.AddWithID(ctx, "", task, 0), ShouldEqual, ErrEmptyDelayMessageID)
+		So(q.AddWithID(ctx, "id", task, 500*time.Millisecond), ShouldBeNil)
+		So(q.Del(ctx, "id"), ShouldBeNil)
+		time.Sleep(time.Second)
+		So(count.Load(), ShouldEqual, 0)
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("stream delay queue dead letter", t, func() {
+		var attempts []int
+		var done = make(chan struct{})
+		var maxRetryTimes = 2
+		q, err := c.NewStreamDelayQueue("mock_dead", func(m *DelayMessage) error {
+			attempts = append(attempts, m.Attempts)
+			if m.Attempts > maxRetryTimes {
+				close(done)
+			}
+			return errors.New("mock error")
+		}, WithStreamDelayOptionPrefix(prefix), WithStreamDelayOptionWorkers(1),
+			WithStreamDelayOptionPollInterval(100*time.Millisecond),
+			WithStreamDelayOptionRetryDelay(100*time.Millisecond),
+			WithStreamDelayOptionRetryTimes(maxRetryTimes))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, q.(*streamDelayQueue).deadKey).Err(), ShouldBeNil)
+
+		So(q.AddWithID(ctx, "dead", task, 0), ShouldBeNil)
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		time.Sleep(200 * time.Millisecond)
+		So(attempts, ShouldResemble, []int{1, 2, 3})
+
+		ms, err := q.DeadLetters(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldEqual, 1)
+		So(ms[0].ID, ShouldEqual, "dead")
+		So(ms[0].Payload, ShouldResemble, task)
+		So(ms[0].Attempts, ShouldEqual, 3)
+
+		l, err := q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(0))
+		So(q.Close(), ShouldBeNil)
+	})
+
+	// 模拟消费者读取任务后崩溃，任务共投递 deliveries 次且都未确认
+	var crash = func(name string, deliveries int) {
+		readyKey := prefix + ":" + fmt.Sprintf(streamDelayReadyKeyFormat, name)
+		dataKey := prefix + ":" + fmt.Sprintf(streamDelayDataKeyFormat, name)
+		versionKey := prefix + ":" + fmt.Sprintf(streamDelayVersionKeyFormat, name)
+		So(c.Del(ctx, readyKey, dataKey, versionKey).Err(), ShouldBeNil)
+		So(c.XGroupCreateMkStream(ctx, readyKey, "redisson", "0").Err(), ShouldBeNil)
+		So(c.HSet(ctx, dataKey, name, task).Err(), ShouldBeNil)
+		sid, err := c.XAdd(ctx, XAddArgs{Stream: readyKey, Values: []string{
+			streamDelayFieldID, name, streamDelayFieldPayload, string(task), streamDelayFieldAttempts, "1",
+		}}).Result()
+		So(err, ShouldBeNil)
+		So(c.HSet(ctx, versionKey, name, sid).Err(), ShouldBeNil)
+		res, err := c.XReadGroup(ctx, XReadGroupArgs{Group: "redisson", Consumer: "crashed", Streams: []string{readyKey, ">"}}).Result()
+		So(err, ShouldBeNil)
+		So(len(res), ShouldEqual, 1)
+		for i := 1; i < deliveries; i++ {
+			So(c.XClaim(ctx, XClaimArgs{Stream: readyKey, Group: "redisson", Consumer: "crashed", Messages: []string{res[0].Messages[0].ID}}).Err(), ShouldBeNil)
+		}
+	}
+
+	Convey("stream delay queue claim", t, func() {
+		crash("mock_claim", 2)
+		var notifyChan = make(chan *DelayMessage, 2)
+		q, err := c.NewStreamDelayQueue("mock_claim", func(m *DelayMessage) error {
+			notifyChan <- m
+			return nil
+		}, WithStreamDelayOptionPrefix(prefix), WithStreamDelayOptionPollInterval(100*time.Millisecond),
+			WithStreamDelayOptionTimeout(100*time.Millisecond))
+		So(err, ShouldBeNil)
+
+		select {
+		case m := <-notifyChan:
+			So(m.ID, ShouldEqual, "mock_claim")
+			So(m.Payload, ShouldResemble, task)
+			// 两次投递未确认，本次为第三次
+			So(m.Attempts, ShouldEqual, 3)
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		time.Sleep(200 * time.Millisecond)
+		l, err := q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(0))
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("stream delay queue claim dead letter", t, func() {
+		crash("mock_claim_dead", 3)
+		var count atomic.Int32
+		q, err := c.NewStreamDelayQueue("mock_claim_dead", func(m *DelayMessage) error {
+			count.Add(1)
+			return nil
+		}, WithStreamDelayOptionPrefix(prefix), WithStreamDelayOptionPollInterval(100*time.Millisecond),
+			WithStreamDelayOptionTimeout(100*time.Millisecond), WithStreamDelayOptionRetryTimes(2))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, q.(*streamDelayQueue).deadKey).Err(), ShouldBeNil)
+
+		var ms []*DelayMessage
+		for i := 0; i < 50 && len(ms) == 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+			ms, err = q.DeadLetters(ctx, 10)
+			So(err, ShouldBeNil)
+		}
+		So(len(ms), ShouldEqual, 1)
+		So(ms[0].ID, ShouldEqual, "mock_claim_dead")
+		So(ms[0].Attempts, ShouldEqual, 3)
+		So(count.Load(), ShouldEqual, 0)
+		So(q.Close(), ShouldBeNil)
+	})
+}
+
+func TestStreamDelayReAdd(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("stream delay queue re-add in flight", t, func() {
+		var notifyChan = make(chan *DelayMessage, 2)
+		var release = make(chan struct{})
+		q, err := c.NewStreamDelayQueue("mock_readd", func(m *DelayMessage) error {
+			notifyChan <- m
+			if string(m.Payload) == "old" {
+				<-release
+			}
+			return nil
+		}, WithStreamDelayOptionPrefix("mock_stream_delay"), WithStreamDelayOptionPollInterval(100*time.Millisecond))
+		So(err, ShouldBeNil)
+
+		So(q.AddWithID(ctx, "id", []byte("old"), 0), ShouldBeNil)
+		select {
+		case m := <-notifyChan:
+			So(string(m.Payload), ShouldEqual, "old")
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		// 旧的投递确认时不能删除重新添加的任务
+		So(q.AddWithID(ctx, "id", []byte("new"), 300*time.Millisecond), ShouldBeNil)
+		close(release)
+		select {
+		case m := <-notifyChan:
+			So(string(m.Payload), ShouldEqual, "new")
+			So(m.Attempts, ShouldEqual, 1)
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		So(q.Close(), ShouldBeNil)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	. "github.com/smartystreets/goconvey/convey"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -137,6 +139,32 @@ func TestDelay(t *testing.T) {
 		So(q.Close(), ShouldBeNil)
 	})
 
+	Convey("delay queue close in callback", t, func() {
+		var closeChan = make(chan error, 1)
+		var q DelayQueue
+		var err error
+		q, err = c.NewDelayQueue(name, func(bytes []byte) error {
+			// 传入已结束的 ctx，不等待当前回调
+			sctx, cancel := context.WithCancel(ctx)
+			cancel()
+			closeChan <- q.Shutdown(sctx)
+			return nil
+		}, WithDelayOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+
+		var addTime = nowFunc()
+		So(q.Add(ctx, task, 0), ShouldBeNil)
+		select {
+		case err = <-closeChan:
+			So(err, ShouldEqual, context.Canceled)
+			// 无需等到 DrainTimeout
+			So(nowFunc().Sub(addTime), ShouldBeLessThan, 3*time.Second)
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		So(q.Close(), ShouldEqual, ErrDelayQueueHasClosed)
+	})
+
 	Convey("delay queue dead letter", t, func() {
 		var notifyChan = make(chan []byte)
 		var q DelayQueue
@@ -163,6 +191,265 @@ func TestDelay(t *testing.T) {
 		So(err, ShouldBeNil)
 		So(l, ShouldEqual, int64(0))
 
+		var ms []*DelayMessage
+		ms, err = q.ListDeadLetters(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldBeGreaterThan, 0)
+		So(ms[len(ms)-1].ID, ShouldEqual, string(task))
+		So(ms[len(ms)-1].Payload, ShouldResemble, task)
+
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue message id", t, func() {
+		var notifyChan = make(chan []byte, 2)
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			notifyChan <- bytes
+			return nil
+		}, WithDelayOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+
+		headers := map[string]string{"trace": "1"}
+		So(q.AddWithID(ctx, "", task, time.Minute, nil), ShouldEqual, ErrEmptyDelayMessageID)
+		So(q.AddWithID(ctx, "a", task, time.Minute, headers), ShouldBeNil)
+		So(q.AddWithID(ctx, "b", task, 2*time.Minute, nil), ShouldBeNil)
+
+		var l int64
+		l, err = q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(2))
+
+		m, err := q.Get(ctx, "a")
+		So(err, ShouldBeNil)
+		So(m.ID, ShouldEqual, "a")
+		So(m.Payload, ShouldResemble, task)
+		So(m.Headers, ShouldResemble, headers)
+		So(m.DueAt.Sub(nowFunc()), ShouldBeGreaterThan, 50*time.Second)
+		_, err = q.Get(ctx, "unknown")
+		So(err, ShouldEqual, ErrDelayTaskNotFound)
+
+		ms, err := q.Peek(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldEqual, 2)
+		So(ms[0].ID, ShouldEqual, "a")
+		So(ms[1].ID, ShouldEqual, "b")
+
+		So(q.Reschedule(ctx, "unknown", 0), ShouldEqual, ErrDelayTaskNotFound)
+		So(q.Reschedule(ctx, "b", 0), ShouldBeNil)
+		select {
+		case data := <-notifyChan:
+			So(data, ShouldResemble, task)
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+
+		So(q.Del(ctx, []byte("a")), ShouldBeNil)
+		time.Sleep(time.Second)
+		l, err = q.Length(ctx)
+		So(err, ShouldBeNil)
+		So(l, ShouldEqual, int64(0))
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue handle message", t, func() {
+		var notifyChan = make(chan *DelayMessage, 1)
+		_, err := c.NewDelayQueue(name, nil, WithDelayOptionPrefix(prefix))
+		So(err, ShouldEqual, ErrEmptyDelayQueueCallback)
+		q, err := c.NewDelayQueue(name, nil, WithDelayOptionPrefix(prefix), WithDelayOptionHandleMessage(func(m *DelayMessage) error {
+			notifyChan <- m
+			return nil
+		}))
+		So(err, ShouldBeNil)
+
+		headers := map[string]string{"trace": "1"}
+		So(q.AddWithID(ctx, "a", task, 0, headers), ShouldBeNil)
+		select {
+		case m := <-notifyChan:
+			So(m.ID, ShouldEqual, "a")
+			So(m.Payload, ShouldResemble, task)
+			So(m.Headers, ShouldResemble, headers)
+			So(m.Attempts, ShouldEqual, 1)
+		case <-time.After(5 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue millisecond precision", t, func() {
+		var doneChan = make(chan time.Time, 1)
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			doneChan <- nowFunc()
+			return nil
+		}, WithDelayOptionPrefix(prefix), WithDelayOptionPollInterval(5*time.Second))
+		So(err, ShouldBeNil)
+		// 等待首次轮询结束
+		time.Sleep(100 * time.Millisecond)
+
+		var delay = 300 * time.Millisecond
+		var addTime = nowFunc()
+		So(q.Add(ctx, task, delay), ShouldBeNil)
+		select {
+		case doTime := <-doneChan:
+			So(doTime.Sub(addTime), ShouldBeGreaterThanOrEqualTo, delay)
+			So(doTime.Sub(addTime), ShouldBeLessThan, delay+200*time.Millisecond)
+		case <-time.After(3 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue workers", t, func() {
+		var running, maxRunning, processed atomic.Int32
+		var workers = 4
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(300 * time.Millisecond)
+			running.Add(-1)
+			processed.Add(1)
+			return nil
+		}, WithDelayOptionPrefix(prefix), WithDelayOptionWorkers(workers), WithDelayOptionBatchSize(3), WithDelayOptionPrefetch(0))
+		So(err, ShouldBeNil)
+
+		var total = 8
+		for i := 0; i < total; i++ {
+			So(q.AddWithID(ctx, strconv.Itoa(i), task, 0, nil), ShouldBeNil)
+		}
+		time.Sleep(100 * time.Millisecond)
+		// 关闭时等待已取出的任务处理完成
+		So(q.Close(), ShouldBeNil)
+		So(running.Load(), ShouldEqual, 0)
+		So(maxRunning.Load(), ShouldBeGreaterThan, 1)
+		So(maxRunning.Load(), ShouldBeLessThanOrEqualTo, workers)
+		So(processed.Load(), ShouldBeGreaterThanOrEqualTo, workers)
+
+		q, err = c.NewDelayQueue(name, func(bytes []byte) error {
+			processed.Add(1)
+			return nil
+		}, WithDelayOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+		time.Sleep(500 * time.Millisecond)
+		So(processed.Load(), ShouldEqual, total)
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue retry error", t, func() {
+		var attempts []int
+		var done = make(chan struct{})
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			attempts = append(attempts, len(attempts)+1)
+			if len(attempts) == 1 {
+				return ErrRetryAfter(200 * time.Millisecond)
+			}
+			close(done)
+			return ErrNoRetry
+		}, WithDelayOptionPrefix(prefix), WithDelayOptionRetryTimes(5), WithDelayOptionRetryPolicy(ExponentialBackoff(time.Minute, 0, 0)))
+		So(err, ShouldBeNil)
+
+		So(q.AddWithID(ctx, "retry", task, 0, nil), ShouldBeNil)
+		select {
+		case <-done:
+		case <-time.After(3 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		time.Sleep(200 * time.Millisecond)
+		So(attempts, ShouldResemble, []int{1, 2})
+
+		var ms []*DelayMessage
+		ms, err = q.ListDeadLetters(ctx, 100)
+		So(err, ShouldBeNil)
+		So(ms[len(ms)-1].ID, ShouldEqual, "retry")
+		So(ms[len(ms)-1].Attempts, ShouldEqual, 2)
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue admin", t, func() {
+		var processed atomic.Int64
+		var fail atomic.Bool
+		fail.Store(true)
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			if fail.Load() {
+				return ErrNoRetry
+			}
+			processed.Add(1)
+			return nil
+		}, WithDelayOptionPrefix(prefix), WithDelayOptionPollInterval(100*time.Millisecond))
+		So(err, ShouldBeNil)
+		_, err = q.Purge(ctx)
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, q.(*delayQueue).deadKey).Err(), ShouldBeNil)
+
+		// 死信重新放入队列
+		So(q.AddWithID(ctx, "admin", task, 0, nil), ShouldBeNil)
+		time.Sleep(300 * time.Millisecond)
+		ms, err := q.ListDeadLetters(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldEqual, 1)
+
+		// 暂停期间不会处理到期任务
+		So(q.Pause(ctx), ShouldBeNil)
+		paused, err := q.IsPaused(ctx)
+		So(err, ShouldBeNil)
+		So(paused, ShouldBeTrue)
+		fail.Store(false)
+		n, err := q.RequeueDeadLetters(ctx, 0)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 1)
+		So(q.AddWithID(ctx, "purge", task, time.Minute, nil), ShouldBeNil)
+		time.Sleep(300 * time.Millisecond)
+		So(processed.Load(), ShouldEqual, 0)
+		ms, err = q.ListInflight(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldEqual, 0)
+
+		So(q.Resume(ctx), ShouldBeNil)
+		time.Sleep(300 * time.Millisecond)
+		So(processed.Load(), ShouldEqual, 1)
+		ms, err = q.ListDeadLetters(ctx, 10)
+		So(err, ShouldBeNil)
+		So(len(ms), ShouldEqual, 0)
+
+		n, err = q.Purge(ctx)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 1)
+		_, err = q.Get(ctx, "purge")
+		So(err, ShouldEqual, ErrDelayTaskNotFound)
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("delay queue oldest due age", t, func() {
+		q, err := c.NewDelayQueue(name, func(bytes []byte) error {
+			return nil
+		}, WithDelayOptionPrefix(prefix), WithDelayOptionPollInterval(100*time.Millisecond))
+		So(err, ShouldBeNil)
+		_, err = q.Purge(ctx)
+		So(err, ShouldBeNil)
+		dq := q.(*delayQueue)
+
+		age, err := oldestDueAge(ctx, dq.c, dq.pollKeys[0])
+		So(err, ShouldBeNil)
+		So(age, ShouldEqual, 0)
+
+		So(q.Pause(ctx), ShouldBeNil)
+		So(q.AddWithID(ctx, "age", task, 0, nil), ShouldBeNil)
+		So(q.AddWithID(ctx, "future", task, time.Minute, nil), ShouldBeNil)
+		time.Sleep(300 * time.Millisecond)
+		age, err = oldestDueAge(ctx, dq.c, dq.pollKeys[0])
+		So(err, ShouldBeNil)
+		So(age, ShouldBeGreaterThanOrEqualTo, 250*time.Millisecond)
+
+		So(q.Resume(ctx), ShouldBeNil)
+		time.Sleep(300 * time.Millisecond)
+		age, err = oldestDueAge(ctx, dq.c, dq.pollKeys[0])
+		So(err, ShouldBeNil)
+		So(age, ShouldEqual, 0)
+		_, err = q.Purge(ctx)
+		So(err, ShouldBeNil)
 		So(q.Close(), ShouldBeNil)
 	})
 }
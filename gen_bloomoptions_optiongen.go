@@ -7,6 +7,10 @@ package redisson
 type BloomOptions struct {
 	// annotation@KeyPrefix(If enabled, Exists and ExistsMulti methods will be available as read-only operations. NOTE: If enabled, minimum redis version should be 7.0.0.)
 	EnableReadOperation bool
+	// annotation@GrowthFactor(comment="可扩展布隆过滤器每一层的容量相对上一层的倍数")
+	GrowthFactor uint `usage:"可扩展布隆过滤器每一层的容量相对上一层的倍数"`
+	// annotation@TighteningRatio(comment="可扩展布隆过滤器每一层的误判率相对上一层的比例，取值范围 (0, 1)")
+	TighteningRatio float64 `usage:"可扩展布隆过滤器每一层的误判率相对上一层的比例，取值范围 (0, 1)"`
 }
 
 // newBloomOptions new BloomOptions
@@ -45,6 +49,24 @@ func WithBloomOptionEnableReadOperation(v bool) BloomOption {
 	}
 }
 
+// WithBloomOptionGrowthFactor 可扩展布隆过滤器每一层的容量相对上一层的倍数
+func WithBloomOptionGrowthFactor(v uint) BloomOption {
+	return func(cc *BloomOptions) BloomOption {
+		previous := cc.GrowthFactor
+		cc.GrowthFactor = v
+		return WithBloomOptionGrowthFactor(previous)
+	}
+}
+
+// WithBloomOptionTighteningRatio 可扩展布隆过滤器每一层的误判率相对上一层的比例，取值范围 (0, 1)
+func WithBloomOptionTighteningRatio(v float64) BloomOption {
+	return func(cc *BloomOptions) BloomOption {
+		previous := cc.TighteningRatio
+		cc.TighteningRatio = v
+		return WithBloomOptionTighteningRatio(previous)
+	}
+}
+
 // InstallBloomOptionsWatchDog the installed func will called when newBloomOptions  called
 func InstallBloomOptionsWatchDog(dog func(cc *BloomOptions)) { watchDogBloomOptions = dog }
 
@@ -55,6 +77,8 @@ var watchDogBloomOptions func(cc *BloomOptions)
 func setBloomOptionsDefaultValue(cc *BloomOptions) {
 	for _, opt := range [...]BloomOption{
 		WithBloomOptionEnableReadOperation(false),
+		WithBloomOptionGrowthFactor(2),
+		WithBloomOptionTighteningRatio(0.5),
 	} {
 		opt(cc)
 	}
@@ -69,10 +93,14 @@ func newDefaultBloomOptions() *BloomOptions {
 
 // all getter func
 func (cc *BloomOptions) GetEnableReadOperation() bool { return cc.EnableReadOperation }
+func (cc *BloomOptions) GetGrowthFactor() uint        { return cc.GrowthFactor }
+func (cc *BloomOptions) GetTighteningRatio() float64  { return cc.TighteningRatio }
 
 // BloomOptionsVisitor visitor interface for BloomOptions
 type BloomOptionsVisitor interface {
 	GetEnableReadOperation() bool
+	GetGrowthFactor() uint
+	GetTighteningRatio() float64
 }
 
 // BloomOptionsInterface visitor + ApplyOption interface for BloomOptions
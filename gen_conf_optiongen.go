@@ -11,23 +11,31 @@ import (
 
 // Conf should use NewConf to initialize it
 type Conf struct {
-	Net               string        `xconf:"net" usage:"网络类型，tcp/unix"`
-	AlwaysRESP2       bool          `xconf:"always_resp2" usage:"always uses RESP2, otherwise it will try using RESP3 first"`
-	Name              string        `xconf:"name" usage:"Redis客户端名字"`
-	MasterName        string        `xconf:"master_name" usage:"Redis Sentinel模式下，master名字"`
-	EnableMonitor     bool          `xconf:"enable_monitor" usage:"是否开启监控"`
-	Addrs             []string      `xconf:"addrs" usage:"Redis地址列表"`
-	DB                int           `xconf:"db" usage:"Redis实例数据库编号，集群下只能用0"`
-	Username          string        `xconf:"username" usage:"Redis用户名"`
-	Password          string        `xconf:"password" usage:"Redis用户密码"`
-	WriteTimeout      time.Duration `xconf:"write_timeout" usage:"Redis连接写入的超时时长"`
-	ConnPoolSize      int           `xconf:"conn_pool_size" usage:"RedisBlock连接池，默认1000"`
-	EnableCache       bool          `xconf:"enable_cache" usage:"是否开启客户端缓存"`
-	CacheSizeEachConn int           `xconf:"cache_size_each_conn" usage:"开启客户端缓存时，单个连接缓存大小，默认128 MiB"`
-	RingScaleEachConn int           `xconf:"ring_scale_each_conn" usage:"单个连接ring buffer大小，默认2 ^ RingScaleEachConn, RingScaleEachConn默认情况下为10"`
-	Development       bool          `xconf:"development" usage:"是否为开发模式，开发模式下，使用部分接口会有警告日志输出，会校验多key是否为同一hash槽，会校验部分接口是否满足版本要求"`
-	T                 Tester        `xconf:"t" usage:"如果设置该值，则启动mock"`
-	ForceSingleClient bool          `xconf:"force_single_client" usage:"ForceSingleClient force the usage of a single client connection, without letting the lib guessing"`
+	Net                   string        `xconf:"net" usage:"网络类型，tcp/unix"`
+	AlwaysRESP2           bool          `xconf:"always_resp2" usage:"always uses RESP2, otherwise it will try using RESP3 first"`
+	Name                  string        `xconf:"name" usage:"Redis客户端名字"`
+	MasterName            string        `xconf:"master_name" usage:"Redis Sentinel模式下，master名字"`
+	EnableMonitor         bool          `xconf:"enable_monitor" usage:"是否开启监控"`
+	Addrs                 []string      `xconf:"addrs" usage:"Redis地址列表"`
+	DB                    int           `xconf:"db" usage:"Redis实例数据库编号，集群下只能用0"`
+	Username              string        `xconf:"username" usage:"Redis用户名"`
+	Password              string        `xconf:"password" usage:"Redis用户密码"`
+	WriteTimeout          time.Duration `xconf:"write_timeout" usage:"Redis连接写入的超时时长"`
+	ConnPoolSize          int           `xconf:"conn_pool_size" usage:"RedisBlock连接池，默认1000"`
+	EnableCache           bool          `xconf:"enable_cache" usage:"是否开启客户端缓存"`
+	CacheSizeEachConn     int           `xconf:"cache_size_each_conn" usage:"开启客户端缓存时，单个连接缓存大小，默认128 MiB"`
+	RingScaleEachConn     int           `xconf:"ring_scale_each_conn" usage:"单个连接ring buffer大小，默认2 ^ RingScaleEachConn, RingScaleEachConn默认情况下为10"`
+	Development           bool          `xconf:"development" usage:"是否为开发模式，开发模式下，使用部分接口会有警告日志输出，会校验多key是否为同一hash槽，会校验部分接口是否满足版本要求"`
+	T                     Tester        `xconf:"t" usage:"如果设置该值，则启动mock"`
+	ForceSingleClient     bool          `xconf:"force_single_client" usage:"ForceSingleClient force the usage of a single client connection, without letting the lib guessing"`
+	Logger                Logger        `xconf:"logger" usage:"日志输出，默认使用 log/slog 输出至标准输出"`
+	Hooks                 []Hook        `xconf:"hooks" usage:"命令钩子，可用于链路追踪、审计日志或故障注入等"`
+	EnableTLS             bool          `xconf:"enable_tls" usage:"是否开启TLS"`
+	TLSCAFile             string        `xconf:"tls_ca_file" usage:"TLS CA证书路径，为空时使用系统证书池，文件变更后新建立的连接会重新加载"`
+	TLSCertFile           string        `xconf:"tls_cert_file" usage:"TLS客户端证书路径，用于mTLS，文件变更后新建立的连接会重新加载"`
+	TLSKeyFile            string        `xconf:"tls_key_file" usage:"TLS客户端私钥路径，用于mTLS，文件变更后新建立的连接会重新加载"`
+	TLSServerName         string        `xconf:"tls_server_name" usage:"TLS校验的服务器名字，为空时使用连接地址"`
+	TLSInsecureSkipVerify bool          `xconf:"tls_insecure_skip_verify" usage:"TLS是否跳过服务器证书校验"`
 }
 
 // NewConf new Conf
@@ -219,6 +227,87 @@ func WithForceSingleClient(v bool) ConfOption {
 	}
 }
 
+// WithLogger 日志输出，默认使用 log/slog 输出至标准输出
+func WithLogger(v Logger) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.Logger
+		cc.Logger = v
+		return WithLogger(previous)
+	}
+}
+
+// WithHooks 命令钩子，可用于链路追踪、审计日志或故障注入等
+func WithHooks(v ...Hook) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.Hooks
+		cc.Hooks = v
+		return WithHooks(previous...)
+	}
+}
+
+// AppendHooks 命令钩子，可用于链路追踪、审计日志或故障注入等
+func AppendHooks(v ...Hook) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.Hooks
+		cc.Hooks = append(cc.Hooks, v...)
+		return WithHooks(previous...)
+	}
+}
+
+// WithEnableTLS 是否开启TLS
+func WithEnableTLS(v bool) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.EnableTLS
+		cc.EnableTLS = v
+		return WithEnableTLS(previous)
+	}
+}
+
+// WithTLSCAFile TLS CA证书路径，为空时使用系统证书池，文件变更后新建立的连接会重新加载
+func WithTLSCAFile(v string) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.TLSCAFile
+		cc.TLSCAFile = v
+		return WithTLSCAFile(previous)
+	}
+}
+
+// WithTLSCertFile TLS客户端证书路径，用于mTLS，文件变更后新建立的连接会重新加载
+func WithTLSCertFile(v string) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.TLSCertFile
+		cc.TLSCertFile = v
+		return WithTLSCertFile(previous)
+	}
+}
+
+// WithTLSKeyFile TLS客户端私钥路径，用于mTLS，文件变更后新建立的连接会重新加载
+func WithTLSKeyFile(v string) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.TLSKeyFile
+		cc.TLSKeyFile = v
+		return WithTLSKeyFile(previous)
+	}
+}
+
+// WithTLSServerName TLS校验的服务器名字，为空时使用连接地址
+func WithTLSServerName(v string) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.TLSServerName
+		cc.TLSServerName = v
+		return WithTLSServerName(previous)
+	}
+}
+
+// WithTLSInsecureSkipVerify TLS是否跳过服务器证书校验
+func WithTLSInsecureSkipVerify(v bool) ConfOption {
+	return func(cc *Conf) ConfOption {
+		previous := cc.TLSInsecureSkipVerify
+		cc.TLSInsecureSkipVerify = v
+		return WithTLSInsecureSkipVerify(previous)
+	}
+}
+
 // InstallConfWatchDog the installed func will called when NewConf  called
 func InstallConfWatchDog(dog func(cc *Conf)) { watchDogConf = dog }
 
@@ -245,6 +334,14 @@ func setConfDefaultValue(cc *Conf) {
 		WithDevelopment(true),
 		WithT(nil),
 		WithForceSingleClient(false),
+		WithLogger(nil),
+		WithHooks(nil...),
+		WithEnableTLS(false),
+		WithTLSCAFile(""),
+		WithTLSCertFile(""),
+		WithTLSKeyFile(""),
+		WithTLSServerName(""),
+		WithTLSInsecureSkipVerify(false),
 	} {
 		opt(cc)
 	}
@@ -312,6 +409,14 @@ func (cc *Conf) GetRingScaleEachConn() int      { return cc.RingScaleEachConn }
 func (cc *Conf) GetDevelopment() bool           { return cc.Development }
 func (cc *Conf) GetT() Tester                   { return cc.T }
 func (cc *Conf) GetForceSingleClient() bool     { return cc.ForceSingleClient }
+func (cc *Conf) GetLogger() Logger              { return cc.Logger }
+func (cc *Conf) GetHooks() []Hook               { return cc.Hooks }
+func (cc *Conf) GetEnableTLS() bool             { return cc.EnableTLS }
+func (cc *Conf) GetTLSCAFile() string           { return cc.TLSCAFile }
+func (cc *Conf) GetTLSCertFile() string         { return cc.TLSCertFile }
+func (cc *Conf) GetTLSKeyFile() string          { return cc.TLSKeyFile }
+func (cc *Conf) GetTLSServerName() string       { return cc.TLSServerName }
+func (cc *Conf) GetTLSInsecureSkipVerify() bool { return cc.TLSInsecureSkipVerify }
 
 // ConfVisitor visitor interface for Conf
 type ConfVisitor interface {
@@ -332,6 +437,14 @@ type ConfVisitor interface {
 	GetDevelopment() bool
 	GetT() Tester
 	GetForceSingleClient() bool
+	GetLogger() Logger
+	GetHooks() []Hook
+	GetEnableTLS() bool
+	GetTLSCAFile() string
+	GetTLSCertFile() string
+	GetTLSKeyFile() string
+	GetTLSServerName() string
+	GetTLSInsecureSkipVerify() bool
 }
 
 // ConfInterface visitor + ApplyOption interface for Conf
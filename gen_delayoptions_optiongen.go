@@ -4,7 +4,6 @@
 package redisson
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -14,10 +13,26 @@ type DelayOptions struct {
 	Prefix string
 	// annotation@Timeout(业务处理超时时间，如果超过该时间未处理，则重试)
 	Timeout time.Duration
+	// annotation@PollInterval(comment="最长轮询间隔，轮询协程会休眠至下一个任务的到期时间，但不超过该间隔")
+	PollInterval time.Duration `usage:"最长轮询间隔，轮询协程会休眠至下一个任务的到期时间，但不超过该间隔"`
+	// annotation@Workers(comment="并发处理任务的协程数")
+	Workers int `usage:"并发处理任务的协程数"`
+	// annotation@BatchSize(comment="每次轮询最多取出的到期任务数量")
+	BatchSize int64 `usage:"每次轮询最多取出的到期任务数量"`
+	// annotation@Prefetch(comment="已取出待处理任务的缓冲区大小，缓冲区中的任务同样受 Timeout 限制")
+	Prefetch int `usage:"已取出待处理任务的缓冲区大小，缓冲区中的任务同样受 Timeout 限制"`
+	// annotation@DrainTimeout(comment="关闭队列时，等待已取出任务处理完成的最长时间")
+	DrainTimeout time.Duration `usage:"关闭队列时，等待已取出任务处理完成的最长时间"`
 	// annotation@RetryTimes(comment="重试次数，当业务处理超时，或业务处理返回错误，则重试")
 	RetryTimes int `usage:"重试次数，当业务处理超时，或业务处理返回错误，则重试"`
-	// annotation@HandleDeadLetter(comment="处理死信，当达到最大重试次数，则为死信")
-	HandleDeadLetter func(bs []byte) `usage:"处理死信，当达到最大重试次数，则为死信"`
+	// annotation@RetryPolicy(comment="重试策略，业务处理返回错误后，根据失败次数计算重试的延迟时间，为 nil 时立即重试")
+	RetryPolicy RetryPolicy `usage:"重试策略，业务处理返回错误后，根据失败次数计算重试的延迟时间，为 nil 时立即重试"`
+	// annotation@HandleMessage(comment="处理消息，设置后代替 NewDelayQueue 的回调，可以获取消息 ID、消息头以及投递次数")
+	HandleMessage func(m *DelayMessage) error `usage:"处理消息，设置后代替 NewDelayQueue 的回调，可以获取消息 ID、消息头以及投递次数"`
+	// annotation@HandleDeadLetter(comment="处理死信，当达到最大重试次数，则为死信，未设置时输出警告日志")
+	HandleDeadLetter func(bs []byte) `usage:"处理死信，当达到最大重试次数，则为死信，未设置时输出警告日志"`
+	// annotation@DeadLetterMaxLen(comment="保留的死信最大数量，可以通过 ListDeadLetters 查看")
+	DeadLetterMaxLen int64 `usage:"保留的死信最大数量，可以通过 ListDeadLetters 查看"`
 }
 
 // newDelayOptions new DelayOptions
@@ -65,6 +80,51 @@ func WithDelayOptionTimeout(v time.Duration) DelayOption {
 	}
 }
 
+// WithDelayOptionPollInterval 最长轮询间隔，轮询协程会休眠至下一个任务的到期时间，但不超过该间隔
+func WithDelayOptionPollInterval(v time.Duration) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.PollInterval
+		cc.PollInterval = v
+		return WithDelayOptionPollInterval(previous)
+	}
+}
+
+// WithDelayOptionWorkers 并发处理任务的协程数
+func WithDelayOptionWorkers(v int) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.Workers
+		cc.Workers = v
+		return WithDelayOptionWorkers(previous)
+	}
+}
+
+// WithDelayOptionBatchSize 每次轮询最多取出的到期任务数量
+func WithDelayOptionBatchSize(v int64) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.BatchSize
+		cc.BatchSize = v
+		return WithDelayOptionBatchSize(previous)
+	}
+}
+
+// WithDelayOptionPrefetch 已取出待处理任务的缓冲区大小，缓冲区中的任务同样受 Timeout 限制
+func WithDelayOptionPrefetch(v int) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.Prefetch
+		cc.Prefetch = v
+		return WithDelayOptionPrefetch(previous)
+	}
+}
+
+// WithDelayOptionDrainTimeout 关闭队列时，等待已取出任务处理完成的最长时间
+func WithDelayOptionDrainTimeout(v time.Duration) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.DrainTimeout
+		cc.DrainTimeout = v
+		return WithDelayOptionDrainTimeout(previous)
+	}
+}
+
 // WithDelayOptionRetryTimes 重试次数，当业务处理超时，或业务处理返回错误，则重试
 func WithDelayOptionRetryTimes(v int) DelayOption {
 	return func(cc *DelayOptions) DelayOption {
@@ -74,7 +134,25 @@ func WithDelayOptionRetryTimes(v int) DelayOption {
 	}
 }
 
-// WithDelayOptionHandleDeadLetter 处理死信，当达到最大重试次数，则为死信
+// WithDelayOptionRetryPolicy 重试策略，业务处理返回错误后，根据失败次数计算重试的延迟时间，为 nil 时立即重试
+func WithDelayOptionRetryPolicy(v RetryPolicy) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.RetryPolicy
+		cc.RetryPolicy = v
+		return WithDelayOptionRetryPolicy(previous)
+	}
+}
+
+// WithDelayOptionHandleMessage 处理消息，设置后代替 NewDelayQueue 的回调，可以获取消息 ID、消息头以及投递次数
+func WithDelayOptionHandleMessage(v func(m *DelayMessage) error) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.HandleMessage
+		cc.HandleMessage = v
+		return WithDelayOptionHandleMessage(previous)
+	}
+}
+
+// WithDelayOptionHandleDeadLetter 处理死信，当达到最大重试次数，则为死信，未设置时输出警告日志
 func WithDelayOptionHandleDeadLetter(v func(bs []byte)) DelayOption {
 	return func(cc *DelayOptions) DelayOption {
 		previous := cc.HandleDeadLetter
@@ -83,6 +161,15 @@ func WithDelayOptionHandleDeadLetter(v func(bs []byte)) DelayOption {
 	}
 }
 
+// WithDelayOptionDeadLetterMaxLen 保留的死信最大数量，可以通过 ListDeadLetters 查看
+func WithDelayOptionDeadLetterMaxLen(v int64) DelayOption {
+	return func(cc *DelayOptions) DelayOption {
+		previous := cc.DeadLetterMaxLen
+		cc.DeadLetterMaxLen = v
+		return WithDelayOptionDeadLetterMaxLen(previous)
+	}
+}
+
 // InstallDelayOptionsWatchDog the installed func will called when newDelayOptions  called
 func InstallDelayOptionsWatchDog(dog func(cc *DelayOptions)) { watchDogDelayOptions = dog }
 
@@ -94,10 +181,16 @@ func setDelayOptionsDefaultValue(cc *DelayOptions) {
 	for _, opt := range [...]DelayOption{
 		WithDelayOptionPrefix(""),
 		WithDelayOptionTimeout(1 * time.Minute),
+		WithDelayOptionPollInterval(time.Second),
+		WithDelayOptionWorkers(1),
+		WithDelayOptionBatchSize(100),
+		WithDelayOptionPrefetch(16),
+		WithDelayOptionDrainTimeout(10 * time.Second),
 		WithDelayOptionRetryTimes(3),
-		WithDelayOptionHandleDeadLetter(func(bs []byte) {
-			warning(fmt.Sprintf("got dead letter, %v", bs))
-		}),
+		WithDelayOptionRetryPolicy(nil),
+		WithDelayOptionHandleMessage(nil),
+		WithDelayOptionHandleDeadLetter(nil),
+		WithDelayOptionDeadLetterMaxLen(10000),
 	} {
 		opt(cc)
 	}
@@ -111,17 +204,33 @@ func newDefaultDelayOptions() *DelayOptions {
 }
 
 // all getter func
-func (cc *DelayOptions) GetPrefix() string                    { return cc.Prefix }
-func (cc *DelayOptions) GetTimeout() time.Duration            { return cc.Timeout }
-func (cc *DelayOptions) GetRetryTimes() int                   { return cc.RetryTimes }
-func (cc *DelayOptions) GetHandleDeadLetter() func(bs []byte) { return cc.HandleDeadLetter }
+func (cc *DelayOptions) GetPrefix() string                             { return cc.Prefix }
+func (cc *DelayOptions) GetTimeout() time.Duration                     { return cc.Timeout }
+func (cc *DelayOptions) GetPollInterval() time.Duration                { return cc.PollInterval }
+func (cc *DelayOptions) GetWorkers() int                               { return cc.Workers }
+func (cc *DelayOptions) GetBatchSize() int64                           { return cc.BatchSize }
+func (cc *DelayOptions) GetPrefetch() int                              { return cc.Prefetch }
+func (cc *DelayOptions) GetDrainTimeout() time.Duration                { return cc.DrainTimeout }
+func (cc *DelayOptions) GetRetryTimes() int                            { return cc.RetryTimes }
+func (cc *DelayOptions) GetRetryPolicy() RetryPolicy                   { return cc.RetryPolicy }
+func (cc *DelayOptions) GetHandleMessage() func(m *DelayMessage) error { return cc.HandleMessage }
+func (cc *DelayOptions) GetHandleDeadLetter() func(bs []byte)          { return cc.HandleDeadLetter }
+func (cc *DelayOptions) GetDeadLetterMaxLen() int64                    { return cc.DeadLetterMaxLen }
 
 // DelayOptionsVisitor visitor interface for DelayOptions
 type DelayOptionsVisitor interface {
 	GetPrefix() string
 	GetTimeout() time.Duration
+	GetPollInterval() time.Duration
+	GetWorkers() int
+	GetBatchSize() int64
+	GetPrefetch() int
+	GetDrainTimeout() time.Duration
 	GetRetryTimes() int
+	GetRetryPolicy() RetryPolicy
+	GetHandleMessage() func(m *DelayMessage) error
 	GetHandleDeadLetter() func(bs []byte)
+	GetDeadLetterMaxLen() int64
 }
 
 // DelayOptionsInterface visitor + ApplyOption interface for DelayOptions
@@ -19,6 +19,12 @@ type LockerOptions struct {
 	NoLoopTracking bool
 	// annotation@FallbackSETPX(Use SET PX instead of SET PXAT when acquiring locks to be compatible with Redis < 6.2)
 	FallbackSETPX bool
+	// annotation@ExtendInterval(ExtendInterval is the interval of extending locks acquired by ReadWriteLocker and ReentrantLocker, and Leases acquired by Locker. Default value is defaultExtendInterval)
+	ExtendInterval time.Duration
+	// annotation@RetryInterval(RetryInterval is the interval of retrying when ReadWriteLocker and ReentrantLocker wait for locks, and Locker waits for the Lease of a previous holder to expire. Default value is defaultRetryInterval)
+	RetryInterval time.Duration
+	// annotation@HolderID(HolderID is the holder ID recorded in the Lease of locks acquired by Locker. Default value is generated from the hostname and pid)
+	HolderID string
 }
 
 // newLockerOptions new LockerOptions
@@ -102,6 +108,33 @@ func WithLockerOptionFallbackSETPX(v bool) LockerOption {
 	}
 }
 
+// WithLockerOptionExtendInterval option func for filed ExtendInterval
+func WithLockerOptionExtendInterval(v time.Duration) LockerOption {
+	return func(cc *LockerOptions) LockerOption {
+		previous := cc.ExtendInterval
+		cc.ExtendInterval = v
+		return WithLockerOptionExtendInterval(previous)
+	}
+}
+
+// WithLockerOptionRetryInterval option func for filed RetryInterval
+func WithLockerOptionRetryInterval(v time.Duration) LockerOption {
+	return func(cc *LockerOptions) LockerOption {
+		previous := cc.RetryInterval
+		cc.RetryInterval = v
+		return WithLockerOptionRetryInterval(previous)
+	}
+}
+
+// WithLockerOptionHolderID option func for filed HolderID
+func WithLockerOptionHolderID(v string) LockerOption {
+	return func(cc *LockerOptions) LockerOption {
+		previous := cc.HolderID
+		cc.HolderID = v
+		return WithLockerOptionHolderID(previous)
+	}
+}
+
 // InstallLockerOptionsWatchDog the installed func will called when newLockerOptions  called
 func InstallLockerOptionsWatchDog(dog func(cc *LockerOptions)) { watchDogLockerOptions = dog }
 
@@ -117,6 +150,9 @@ func setLockerOptionsDefaultValue(cc *LockerOptions) {
 		WithLockerOptionKeyMajority(defaultKeyMajority),
 		WithLockerOptionNoLoopTracking(false),
 		WithLockerOptionFallbackSETPX(false),
+		WithLockerOptionExtendInterval(defaultExtendInterval),
+		WithLockerOptionRetryInterval(defaultRetryInterval),
+		WithLockerOptionHolderID(""),
 	} {
 		opt(cc)
 	}
@@ -130,12 +166,15 @@ func newDefaultLockerOptions() *LockerOptions {
 }
 
 // all getter func
-func (cc *LockerOptions) GetKeyPrefix() string           { return cc.KeyPrefix }
-func (cc *LockerOptions) GetKeyValidity() time.Duration  { return cc.KeyValidity }
-func (cc *LockerOptions) GetTryNextAfter() time.Duration { return cc.TryNextAfter }
-func (cc *LockerOptions) GetKeyMajority() int32          { return cc.KeyMajority }
-func (cc *LockerOptions) GetNoLoopTracking() bool        { return cc.NoLoopTracking }
-func (cc *LockerOptions) GetFallbackSETPX() bool         { return cc.FallbackSETPX }
+func (cc *LockerOptions) GetKeyPrefix() string             { return cc.KeyPrefix }
+func (cc *LockerOptions) GetKeyValidity() time.Duration    { return cc.KeyValidity }
+func (cc *LockerOptions) GetTryNextAfter() time.Duration   { return cc.TryNextAfter }
+func (cc *LockerOptions) GetKeyMajority() int32            { return cc.KeyMajority }
+func (cc *LockerOptions) GetNoLoopTracking() bool          { return cc.NoLoopTracking }
+func (cc *LockerOptions) GetFallbackSETPX() bool           { return cc.FallbackSETPX }
+func (cc *LockerOptions) GetExtendInterval() time.Duration { return cc.ExtendInterval }
+func (cc *LockerOptions) GetRetryInterval() time.Duration  { return cc.RetryInterval }
+func (cc *LockerOptions) GetHolderID() string              { return cc.HolderID }
 
 // LockerOptionsVisitor visitor interface for LockerOptions
 type LockerOptionsVisitor interface {
@@ -145,6 +184,9 @@ type LockerOptionsVisitor interface {
 	GetKeyMajority() int32
 	GetNoLoopTracking() bool
 	GetFallbackSETPX() bool
+	GetExtendInterval() time.Duration
+	GetRetryInterval() time.Duration
+	GetHolderID() string
 }
 
 // LockerOptionsInterface visitor + ApplyOption interface for LockerOptions
@@ -13,6 +13,7 @@ require (
 	github.com/redis/rueidis/rueidisprob v1.0.49
 	github.com/sandwich-go/funnel v0.0.1
 	github.com/smartystreets/goconvey v1.7.2
+	github.com/twmb/murmur3 v1.1.8
 )
 
 require (
@@ -27,7 +28,6 @@ require (
 	github.com/prometheus/common v0.26.0 // indirect
 	github.com/prometheus/procfs v0.6.0 // indirect
 	github.com/smartystreets/assertions v1.2.0 // indirect
-	github.com/twmb/murmur3 v1.1.8 // indirect
 	github.com/yuin/gopher-lua v1.1.0 // indirect
 	golang.org/x/sys v0.24.0 // indirect
 	google.golang.org/protobuf v1.34.2 // indirect
@@ -0,0 +1,62 @@
+package redisson
+
+import "context"
+
+// Hook 命令钩子，可以通过 WithHooks 进行设置，用于链路追踪、审计日志或故障注入等
+// 多个 Hook 时，Before 按照设置顺序调用，After 按照设置的逆序调用
+type Hook interface {
+	// BeforeProcess 命令执行前调用，返回的 context 会传递给 AfterProcess
+	// keys 为命令涉及的 key，无法获得时为 nil
+	BeforeProcess(ctx context.Context, command Command, keys []string) context.Context
+	// AfterProcess 命令执行后调用
+	AfterProcess(ctx context.Context, command Command, keys []string, err error)
+	// BeforePipeline 管道或事务执行前调用，返回的 context 会传递给 AfterPipeline
+	BeforePipeline(ctx context.Context, command Command, cmds []Completed) context.Context
+	// AfterPipeline 管道或事务执行后调用
+	AfterPipeline(ctx context.Context, command Command, cmds []Completed, err error)
+}
+
+type hookContextKeyType struct{}
+
+func (*hookContextKeyType) String() string { return "hook" }
+
+var hookContextKey = hookContextKeyType(struct{}{})
+
+type hookState struct {
+	command  Command
+	keys     []string
+	cmds     []Completed
+	pipeline bool
+}
+
+func beforeProcessHooks(ctx context.Context, hooks []Hook, command Command, getKeys func() []string) context.Context {
+	var keys []string
+	if getKeys != nil {
+		keys = getKeys()
+	}
+	for _, h := range hooks {
+		ctx = h.BeforeProcess(ctx, command, keys)
+	}
+	return context.WithValue(ctx, hookContextKey, &hookState{command: command, keys: keys})
+}
+
+func beforePipelineHooks(ctx context.Context, hooks []Hook, command Command, cmds []Completed) context.Context {
+	for _, h := range hooks {
+		ctx = h.BeforePipeline(ctx, command, cmds)
+	}
+	return context.WithValue(ctx, hookContextKey, &hookState{command: command, cmds: cmds, pipeline: true})
+}
+
+func afterHooks(ctx context.Context, hooks []Hook, err error) {
+	st, ok := ctx.Value(hookContextKey).(*hookState)
+	if !ok {
+		return
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if st.pipeline {
+			hooks[i].AfterPipeline(ctx, st.command, st.cmds, err)
+		} else {
+			hooks[i].AfterProcess(ctx, st.command, st.keys, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package redisson
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type recordHook struct {
+	mx       sync.Mutex
+	before   []string
+	after    []string
+	keys     [][]string
+	pipeline []int
+	errs     []error
+}
+
+func (h *recordHook) BeforeProcess(ctx context.Context, command Command, keys []string) context.Context {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.before = append(h.before, command.String())
+	h.keys = append(h.keys, keys)
+	return ctx
+}
+
+func (h *recordHook) AfterProcess(_ context.Context, command Command, _ []string, err error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.after = append(h.after, command.String())
+	h.errs = append(h.errs, err)
+}
+
+func (h *recordHook) BeforePipeline(ctx context.Context, command Command, cmds []Completed) context.Context {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.before = append(h.before, command.String())
+	h.pipeline = append(h.pipeline, len(cmds))
+	return ctx
+}
+
+func (h *recordHook) AfterPipeline(_ context.Context, command Command, _ []Completed, err error) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.after = append(h.after, command.String())
+	h.errs = append(h.errs, err)
+}
+
+func (h *recordHook) reset() {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.before, h.after, h.keys, h.pipeline, h.errs = nil, nil, nil, nil, nil
+}
+
+func TestHook(t *testing.T) {
+	h := &recordHook{}
+	c := MustNewClient(NewConf(WithDevelopment(false), WithHooks(h)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var key = "hook_key"
+
+	Convey("hook", t, func() {
+		h.reset()
+		So(c.Set(ctx, key, "value", 0).Err(), ShouldBeNil)
+		So(c.Get(ctx, key).Val(), ShouldEqual, "value")
+
+		pip := c.Pipeline()
+		CommandGet.P(pip).Cmd(key)
+		CommandDel.P(pip).Cmd(key)
+		_, err := pip.Exec(ctx)
+		So(err, ShouldBeNil)
+
+		h.mx.Lock()
+		defer h.mx.Unlock()
+		So(h.before, ShouldResemble, []string{"SET", "GET", "PIPELINE"})
+		So(h.after, ShouldResemble, h.before)
+		So(h.keys, ShouldResemble, [][]string{{key}, {key}})
+		So(h.pipeline, ShouldResemble, []int{2})
+		So(h.errs, ShouldResemble, []error{nil, nil, nil})
+	})
+
+	Convey("hook in watch", t, func() {
+		h.reset()
+		err := c.Watch(ctx, func(tx Tx) error {
+			return tx.Do(ctx, c.(*client).cmd.B().Set().Key(key).Value("value").Build()).Error()
+		}, key)
+		So(err, ShouldBeNil)
+
+		h.mx.Lock()
+		defer h.mx.Unlock()
+		So(h.before, ShouldResemble, []string{"WATCH", "SET", "UNWATCH"})
+		So(h.after, ShouldResemble, h.before)
+		So(h.keys, ShouldResemble, [][]string{{key}, {key}, nil})
+		So(h.errs, ShouldResemble, []error{nil, nil, nil})
+	})
+}
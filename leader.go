@@ -0,0 +1,229 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/rueidis/rueidislock"
+)
+
+var (
+	ErrEmptyLeaderElectorName = errors.New("leader elector name cannot be empty")
+	ErrEmptyLeaderCallback    = errors.New("leader elected callback cannot be empty")
+	ErrLeaderElectorRunning   = errors.New("leader elector is running")
+	ErrLeaderElectorHasClosed = errors.New("leader elector has closed")
+	ErrNoLeader               = errors.New("no leader")
+)
+
+const (
+	leaderLogPrefix     = "[redis-leader]:"
+	leaderChannelFormat = "%s:leader:{%s}"
+	// 通知内容为 "事件:实例 ID"
+	leaderEventElected   = "elected"
+	leaderEventRevoked   = "revoked"
+	leaderEventSeparator = ":"
+	// leaderResubscribeInterval 订阅断开后重新订阅的间隔
+	leaderResubscribeInterval = time.Second
+)
+
+// LeaderElector 基于 Locker 的领导者选举，同一时刻只有一个实例成为领导者
+// 领导者变更时会通过 pub/sub 通知其他实例，以便及时接替
+type LeaderElector interface {
+	// Run 参与选举，直到 ctx 结束或者 Close 被调用
+	// 成为领导者后调用 onElected，失去领导地位时 onElected 的 ctx 被取消，待 onElected 返回后调用 onRevoked
+	Run(ctx context.Context, onElected func(ctx context.Context), onRevoked func()) error
+	// IsLeader 当前实例是否为领导者
+	IsLeader() bool
+	// Leader 返回当前领导者的 ID，没有领导者时返回 ErrNoLeader
+	Leader(ctx context.Context) (string, error)
+	// ID 当前实例的 ID
+	ID() string
+	// Close 停止参与选举，当前实例为领导者时会主动释放并通知其他实例接替
+	Close() error
+}
+
+type leaderElector struct {
+	c       *client
+	spec    LeaderOptionsVisitor
+	name    string
+	id      string
+	channel string
+	locker  *wrapLocker
+
+	leader  atomic.Bool
+	running atomic.Bool
+	closed  atomic.Bool
+	exitC   chan struct{}
+	doneC   chan struct{}
+	notifyC chan struct{}
+}
+
+func newLeaderElector(c *client, name string, opts ...LeaderOption) (*leaderElector, error) {
+	if name == "" {
+		return nil, ErrEmptyLeaderElectorName
+	}
+	spec := newLeaderOptions(opts...)
+	id := spec.GetID()
+	if id == "" {
+		id = defaultLockHolderID()
+	}
+	l, err := newLocker(c, WithLockerOptionKeyPrefix(spec.GetKeyPrefix()), WithLockerOptionKeyValidity(spec.GetKeyValidity()), WithLockerOptionHolderID(id))
+	if err != nil {
+		return nil, err
+	}
+	return &leaderElector{
+		c:       c,
+		spec:    spec,
+		name:    name,
+		id:      id,
+		channel: fmt.Sprintf(leaderChannelFormat, spec.GetKeyPrefix(), name),
+		locker:  l.(*wrapLocker),
+		exitC:   make(chan struct{}),
+		doneC:   make(chan struct{}),
+		notifyC: make(chan struct{}, 1),
+	}, nil
+}
+
+func (l *leaderElector) ID() string     { return l.id }
+func (l *leaderElector) IsLeader() bool { return l.leader.Load() }
+
+func (l *leaderElector) Leader(ctx context.Context) (string, error) {
+	lease, err := l.locker.Inspect(ctx, l.name)
+	if err != nil {
+		if errors.Is(err, ErrLockNotHeld) {
+			err = ErrNoLeader
+		}
+		return "", err
+	}
+	return lease.Holder, nil
+}
+
+func (l *leaderElector) Run(ctx context.Context, onElected func(ctx context.Context), onRevoked func()) error {
+	if onElected == nil {
+		return ErrEmptyLeaderCallback
+	}
+	if l.closed.Load() {
+		return ErrLeaderElectorHasClosed
+	}
+	if !l.running.CompareAndSwap(false, true) {
+		return ErrLeaderElectorRunning
+	}
+	defer close(l.doneC)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go l.subscribe(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.exitC:
+			return nil
+		default:
+		}
+		// 领导者的锁持续到失去领导地位，不受 WriteTimeout 限制
+		lctx, release, _, err := l.locker.lease(ctx, l.name, l.locker.Locker.TryWithContext, false, false)
+		if err == nil {
+			l.lead(lctx, release, onElected, onRevoked)
+			continue
+		}
+		if !errors.Is(err, rueidislock.ErrNotLocked) && ctx.Err() == nil {
+			e(l.c.v, leaderLogPrefix+" elect failed", Any("name", l.name), errorField(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.exitC:
+			return nil
+		case <-l.notifyC:
+		case <-time.After(l.spec.GetRetryInterval()):
+		}
+	}
+}
+
+// lead 保持领导地位直到锁丢失、ctx 结束或者 Close 被调用
+func (l *leaderElector) lead(lctx context.Context, release context.CancelFunc, onElected func(ctx context.Context), onRevoked func()) {
+	l.leader.Store(true)
+	l.publish(leaderEventElected)
+	ctx, cancel := context.WithCancel(lctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.call(leaderEventElected, func() { onElected(ctx) })
+	}()
+	select {
+	case <-lctx.Done():
+	case <-l.exitC:
+	}
+	cancel()
+	<-done
+	release()
+	l.leader.Store(false)
+	if onRevoked != nil {
+		l.call(leaderEventRevoked, onRevoked)
+	}
+	l.publish(leaderEventRevoked)
+}
+
+func (l *leaderElector) call(event string, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			e(l.c.v, leaderLogPrefix+" callback panic", Any("name", l.name), Any("event", event), Any("panic", r))
+		}
+	}()
+	f()
+}
+
+func (l *leaderElector) publish(event string) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.c.v.GetWriteTimeout())
+	defer cancel()
+	if err := l.c.Publish(ctx, l.channel, event+leaderEventSeparator+l.id).Err(); err != nil {
+		warning(l.c.v, leaderLogPrefix+" publish failed", Any("name", l.name), Any("event", event), errorField(err))
+	}
+}
+
+// subscribe 收到其他实例的领导者变更通知后，立即重新竞选
+func (l *leaderElector) subscribe(ctx context.Context) {
+	for {
+		err := l.c.Receive(ctx, func(msg Message) {
+			if strings.HasSuffix(msg.Message, leaderEventSeparator+l.id) {
+				return
+			}
+			select {
+			case l.notifyC <- struct{}{}:
+			default:
+			}
+		}, l.channel)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			warning(l.c.v, leaderLogPrefix+" subscribe failed", Any("name", l.name), errorField(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(leaderResubscribeInterval):
+		}
+	}
+}
+
+func (l *leaderElector) Close() error {
+	if !l.closed.CompareAndSwap(false, true) {
+		return ErrLeaderElectorHasClosed
+	}
+	close(l.exitC)
+	if l.running.Load() {
+		<-l.doneC
+	}
+	l.locker.Locker.Close()
+	return nil
+}
+
+// NewLeaderElector 新建一个领导者选举，相同 name 的实例竞选同一个领导者
+func (c *client) NewLeaderElector(name string, opts ...LeaderOption) (LeaderElector, error) {
+	return newLeaderElector(c, name, opts...)
+}
@@ -0,0 +1,67 @@
+package redisson
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLeaderElector(t *testing.T) {
+	// 测试使用的服务器不支持在 RESP3 连接上同时订阅以及执行其他命令，RESP2 下订阅使用独立的连接
+	c := MustNewClient(NewConf(WithDevelopment(false), WithAlwaysRESP2(true)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("leader handoff", t, func() {
+		var opts = []LeaderOption{WithLeaderOptionKeyPrefix("mock_leader"), WithLeaderOptionRetryInterval(time.Minute)}
+		e1, err := c.NewLeaderElector("election", append(opts, WithLeaderOptionID("e1"))...)
+		So(err, ShouldBeNil)
+		e2, err := c.NewLeaderElector("election", append(opts, WithLeaderOptionID("e2"))...)
+		So(err, ShouldBeNil)
+		So(e1.Run(ctx, nil, nil), ShouldEqual, ErrEmptyLeaderCallback)
+
+		var elected1, revoked1, elected2 atomic.Int32
+		var run1, run2 = make(chan error, 1), make(chan error, 1)
+		go func() {
+			run1 <- e1.Run(ctx, func(ctx context.Context) {
+				elected1.Add(1)
+				<-ctx.Done()
+			}, func() {
+				revoked1.Add(1)
+			})
+		}()
+		time.Sleep(300 * time.Millisecond)
+		So(e1.IsLeader(), ShouldBeTrue)
+		go func() {
+			run2 <- e2.Run(ctx, func(ctx context.Context) {
+				elected2.Add(1)
+			}, nil)
+		}()
+		time.Sleep(300 * time.Millisecond)
+		So(e2.IsLeader(), ShouldBeFalse)
+		leader, err := e2.Leader(ctx)
+		So(err, ShouldBeNil)
+		So(leader, ShouldEqual, "e1")
+
+		// 领导者关闭后，其他实例通过通知立即接替，无需等待 RetryInterval
+		So(e1.Close(), ShouldBeNil)
+		So(<-run1, ShouldBeNil)
+		So(elected1.Load(), ShouldEqual, 1)
+		So(revoked1.Load(), ShouldEqual, 1)
+		time.Sleep(500 * time.Millisecond)
+		So(e2.IsLeader(), ShouldBeTrue)
+		So(elected2.Load(), ShouldEqual, 1)
+		leader, err = e1.Leader(ctx)
+		So(err, ShouldBeNil)
+		So(leader, ShouldEqual, "e2")
+
+		So(e2.Close(), ShouldBeNil)
+		So(<-run2, ShouldBeNil)
+		So(e2.Close(), ShouldEqual, ErrLeaderElectorHasClosed)
+	})
+}
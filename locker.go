@@ -2,11 +2,90 @@ package redisson
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/redis/rueidis"
 	"github.com/redis/rueidis/rueidislock"
 )
 
+// 锁的持有信息，fence 为单调递增的 fencing token，释放锁后保留
+// 其他持有者的租约未过期时不生成新的 fencing token，避免已丢失锁的持有者获得更大的 token，过期时间使用 Redis 服务器的时间
+var lockLeaseFuncLua = `
+if redis.replicate_commands then
+	redis.replicate_commands()
+end
+local function server_now()
+	local t = redis.call('TIME')
+	return tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+end
+`
+
+var recordLockLeaseLua = lockLeaseFuncLua + `
+local lease_hash = KEYS[1]
+local holder, validity, ttl = ARGV[1], tonumber(ARGV[2]), ARGV[3]
+local now = server_now()
+if redis.call('HEXISTS', lease_hash, 'holder') == 1 and tonumber(redis.call('HGET', lease_hash, 'expire') or '0') > now then
+	return {0, 0}
+end
+local fence = tonumber(redis.call('HGET', lease_hash, 'fence') or '0') + 1
+if fence < now then
+	fence = now
+end
+redis.call('HSET', lease_hash, 'fence', fence)
+redis.call('HSET', lease_hash, 'holder', holder)
+redis.call('HSET', lease_hash, 'expire', now + validity)
+redis.call('PEXPIRE', lease_hash, ttl)
+return {fence, now + validity}
+`
+
+var extendLockLeaseLua = lockLeaseFuncLua + `
+local lease_hash = KEYS[1]
+local holder, fence, validity = ARGV[1], ARGV[2], tonumber(ARGV[3])
+if redis.call('HGET', lease_hash, 'holder') ~= holder or redis.call('HGET', lease_hash, 'fence') ~= fence then
+	return 0
+end
+local expire = server_now() + validity
+redis.call('HSET', lease_hash, 'expire', expire)
+return expire
+`
+
+var clearLockLeaseLua = `
+local lease_hash = KEYS[1]
+local holder, fence = ARGV[1], ARGV[2]
+if redis.call('HGET', lease_hash, 'holder') == holder and redis.call('HGET', lease_hash, 'fence') == fence then
+	redis.call('HDEL', lease_hash, 'holder', 'expire')
+	return 1
+end
+return 0
+`
+
+var ErrLockNotHeld = errors.New("lock is not held")
+
+const (
+	lockLeaseKeyFormat = "%s:lease:%s"
+	// lockLeaseKeyTTL 锁的持有信息保留的时间，过期后 fencing token 以 Redis 服务器当前时间的毫秒数为下限，依然保证单调递增
+	lockLeaseKeyTTL = 7 * 24 * time.Hour
+)
+
+// Lease is the holding information of a lock.
+type Lease struct {
+	// Name is the name of the lock.
+	Name string
+	// Holder is the holder ID of the lock, see WithLockerOptionHolderID.
+	Holder string
+	// Token is the fencing token which increases monotonically on every acquisition of the same lock.
+	// Pass it to downstream storage to reject writes from stale holders.
+	Token int64
+	// ExpiresAt is the expiry of the lock when the Lease is returned, by the clock of redis server.
+	// The lock is extended automatically until it is released or lost, but ExpiresAt is not updated, use Inspect to get the current expiry.
+	ExpiresAt time.Time
+}
+
 // Locker is the interface of lock
 type Locker interface {
 	// WithContext acquires a distributed redis lock by name by waiting for it. It may return ErrLockerClosed.
@@ -15,6 +94,16 @@ type Locker interface {
 	TryWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
 	// ForceWithContext takes over a distributed redis lock by canceling the original holder. It may return ErrNotLocked.
 	ForceWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
+	// LeaseWithContext acquires a distributed redis lock by name by waiting for it, and returns the Lease with a fencing token.
+	// The returned context is canceled when the lock or the Lease is lost.
+	LeaseWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, *Lease, error)
+	// TryLeaseWithContext tries to acquire a distributed redis lock by name without waiting, and returns the Lease with a fencing token.
+	// It may return ErrNotLocked, also when the Lease of a previous holder has not expired yet.
+	TryLeaseWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, *Lease, error)
+	// Inspect returns the Lease of the lock held currently. It may return ErrLockNotHeld.
+	Inspect(ctx context.Context, name string) (*Lease, error)
+	// ListHeld returns the Leases of the locks held currently whose names start with the prefix.
+	ListHeld(ctx context.Context, prefix string) ([]*Lease, error)
 }
 
 const fallbackSETPXVersion = "6.2.0"
@@ -22,6 +111,19 @@ const fallbackSETPXVersion = "6.2.0"
 type wrapLocker struct {
 	v ConfInterface
 	rueidislock.Locker
+
+	c      *client
+	spec   LockerOptionsVisitor
+	holder string
+
+	recordScript Scripter
+	extendScript Scripter
+	clearScript  Scripter
+}
+
+func defaultLockHolderID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), newLockToken()[:8])
 }
 
 func (w *wrapLocker) wrap(ctx context.Context, name string, f func(ctx context.Context, name string) (context.Context, context.CancelFunc, error)) (context.Context, context.CancelFunc, error) {
@@ -38,6 +140,114 @@ func (w *wrapLocker) wrap(ctx context.Context, name string, f func(ctx context.C
 	}, err
 }
 
+// lease 获取锁后记录持有信息并生成 fencing token，持有信息与锁一起定期延长，任意一个丢失时取消返回的 context
+// timeout 为 true 且 ctx 未设置超时时间时，使用 WriteTimeout 作为超时时间，此时锁最长持有 WriteTimeout
+func (w *wrapLocker) lease(ctx context.Context, name string, f func(ctx context.Context, name string) (context.Context, context.CancelFunc, error), wait, timeout bool) (context.Context, context.CancelFunc, *Lease, error) {
+	var cancel0 context.CancelFunc = func() {}
+	ctx0 := ctx
+	if _, ok := ctx.Deadline(); !ok && timeout {
+		ctx0, cancel0 = context.WithTimeout(ctx, w.v.GetWriteTimeout())
+	}
+	start := nowFunc()
+	ctx1, cancel1, err := f(ctx0, name)
+	if err != nil {
+		return ctx1, func() {
+			if cancel1 != nil {
+				cancel1()
+			}
+			cancel0()
+		}, nil, err
+	}
+	w.c.handler.lockWait(w.spec.GetKeyPrefix(), nowFunc().Sub(start))
+	lease, err := w.record(ctx1, name, wait)
+	if err != nil {
+		cancel1()
+		cancel0()
+		return ctx1, cancel1, nil, err
+	}
+	ctx2, cancel2 := context.WithCancel(ctx1)
+	released := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.hold(ctx2, cancel2, lease)
+		select {
+		case <-released:
+		default:
+			if ctx0.Err() == nil {
+				// 锁已丢失
+				w.c.handler.lockLost(w.spec.GetKeyPrefix())
+				warning(w.c.v, "[redis-locker]: lock lost", Any("name", name), Any("token", lease.Token))
+			}
+		}
+		w.clear(lease)
+	}()
+	return ctx2, func() {
+		select {
+		case <-released:
+		default:
+			close(released)
+		}
+		cancel2()
+		cancel1()
+		cancel0()
+		<-done
+	}, lease, nil
+}
+
+// record 记录锁的持有信息，并生成 fencing token，其他持有者的租约未过期时，wait 为 true 则等待其过期
+func (w *wrapLocker) record(ctx context.Context, name string, wait bool) (*Lease, error) {
+	for {
+		res, err := w.recordScript.Run(ctx, []string{w.leaseKey(name)}, w.holder, w.spec.GetKeyValidity().Milliseconds(), lockLeaseKeyTTL.Milliseconds()).Int64Slice()
+		if err != nil {
+			return nil, err
+		}
+		if len(res) == 2 && res[0] > 0 {
+			return &Lease{Name: name, Holder: w.holder, Token: res[0], ExpiresAt: time.UnixMilli(res[1])}, nil
+		}
+		if !wait {
+			return nil, rueidislock.ErrNotLocked
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(w.spec.GetRetryInterval()):
+		}
+	}
+}
+
+// hold 定期延长持有信息的有效期，ctx 结束或者持有信息已丢失时返回
+func (w *wrapLocker) hold(ctx context.Context, cancel context.CancelFunc, lease *Lease) {
+	defer cancel()
+	t := time.NewTicker(w.spec.GetExtendInterval())
+	defer t.Stop()
+	extended := nowFunc()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			now := nowFunc()
+			n, err := w.extendScript.Run(ctx, []string{w.leaseKey(lease.Name)}, lease.Holder, lease.Token, w.spec.GetKeyValidity().Milliseconds()).Int64()
+			if err == nil && n > 0 {
+				extended = now
+			} else if (err == nil && n == 0) || now.Sub(extended) >= w.spec.GetKeyValidity() {
+				return
+			}
+		}
+	}
+}
+
+func (w *wrapLocker) clear(lease *Lease) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.v.GetWriteTimeout())
+	defer cancel()
+	_ = w.clearScript.Run(ctx, []string{w.leaseKey(lease.Name)}, lease.Holder, lease.Token).Err()
+}
+
+func (w *wrapLocker) leaseKey(name string) string {
+	return fmt.Sprintf(lockLeaseKeyFormat, w.spec.GetKeyPrefix(), name)
+}
+
 func (w *wrapLocker) WithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error) {
 	return w.wrap(ctx, name, w.Locker.WithContext)
 }
@@ -52,6 +262,90 @@ func (w *wrapLocker) ForceWithContext(ctx context.Context, name string) (context
 	return w.wrap(ctx, name, w.Locker.ForceWithContext)
 }
 
+func (w *wrapLocker) LeaseWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, *Lease, error) {
+	return w.lease(ctx, name, w.Locker.WithContext, true, true)
+}
+
+func (w *wrapLocker) TryLeaseWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, *Lease, error) {
+	return w.lease(ctx, name, w.Locker.TryWithContext, false, true)
+}
+
+// Inspect 根据 rueidislock 的 key 判断锁是否被持有，持有者以及 fencing token 来自持有信息
+func (w *wrapLocker) Inspect(ctx context.Context, name string) (*Lease, error) {
+	majority := w.spec.GetKeyMajority()
+	if majority <= 0 {
+		majority = defaultKeyMajority
+	}
+	var held int32
+	var ttl time.Duration
+	for i := int32(0); i < majority*2-1; i++ {
+		d, err := w.c.PTTL(ctx, fmt.Sprintf("%s:%d:%s", w.spec.GetKeyPrefix(), i, name)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if d > 0 {
+			held++
+			if d > ttl {
+				ttl = d
+			}
+		}
+	}
+	if held < majority {
+		return nil, ErrLockNotHeld
+	}
+	vals, err := w.c.HMGet(ctx, w.leaseKey(name), "holder", "fence").Result()
+	if err != nil {
+		return nil, err
+	}
+	lease := &Lease{Name: name, ExpiresAt: nowFunc().Add(ttl)}
+	if v, ok := vals[0].(string); ok {
+		lease.Holder = v
+	}
+	if v, ok := vals[1].(string); ok {
+		lease.Token, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return lease, nil
+}
+
+func (w *wrapLocker) ListHeld(ctx context.Context, prefix string) ([]*Lease, error) {
+	keyPrefix := w.leaseKey("")
+	match := globEscaper.Replace(keyPrefix+prefix) + "*"
+	names := make(map[string]struct{})
+	err := w.c.ForEachNodes(ctx, func(ctx context.Context, c Cmdable) error {
+		var cursor uint64
+		for {
+			keys, next, err := c.Scan(ctx, cursor, match, 100).Result()
+			if err != nil {
+				return err
+			}
+			for _, k := range keys {
+				names[strings.TrimPrefix(k, keyPrefix)] = struct{}{}
+			}
+			if cursor = next; cursor == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	leases := make([]*Lease, 0, len(names))
+	for name := range names {
+		lease, err0 := w.Inspect(ctx, name)
+		if err0 != nil {
+			if errors.Is(err0, ErrLockNotHeld) {
+				continue
+			}
+			return nil, err0
+		}
+		leases = append(leases, lease)
+	}
+	return leases, nil
+}
+
+// globEscaper 转义 SCAN MATCH 中的特殊字符
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
 // newLocker 新建一个 locker
 func newLocker(c *client, opts ...LockerOption) (Locker, error) {
 	// 校验版本
@@ -59,6 +353,10 @@ func newLocker(c *client, opts ...LockerOption) (Locker, error) {
 		opts = append(opts, WithLockerOptionFallbackSETPX(true))
 	}
 	cc := newLockerOptions(opts...)
+	clientOption, err := confVisitor2ClientOption(c.v)
+	if err != nil {
+		return nil, err
+	}
 	l, err := rueidislock.NewLocker(rueidislock.LockerOption{
 		KeyPrefix:      cc.GetKeyPrefix(),
 		KeyValidity:    cc.GetKeyValidity(),
@@ -66,7 +364,7 @@ func newLocker(c *client, opts ...LockerOption) (Locker, error) {
 		KeyMajority:    cc.GetKeyMajority(),
 		NoLoopTracking: cc.GetNoLoopTracking(),
 		FallbackSETPX:  cc.GetFallbackSETPX(),
-		ClientOption:   confVisitor2ClientOption(c.v),
+		ClientOption:   clientOption,
 		ClientBuilder: func(option rueidis.ClientOption) (rueidis.Client, error) {
 			return rueidis.NewClient(option)
 		},
@@ -74,7 +372,20 @@ func newLocker(c *client, opts ...LockerOption) (Locker, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &wrapLocker{Locker: l, v: c.v}, nil
+	holder := cc.GetHolderID()
+	if holder == "" {
+		holder = defaultLockHolderID()
+	}
+	return &wrapLocker{
+		Locker:       l,
+		v:            c.v,
+		c:            c,
+		spec:         cc,
+		holder:       holder,
+		recordScript: c.CreateScript(recordLockLeaseLua),
+		extendScript: c.CreateScript(extendLockLeaseLua),
+		clearScript:  c.CreateScript(clearLockLeaseLua),
+	}, nil
 }
 
 // NewLocker 新建一个 locker
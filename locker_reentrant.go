@@ -0,0 +1,41 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrEmptyLockOwner = errors.New("lock owner cannot be empty")
+
+// ReentrantLocker is the interface of reentrant lock. The same owner can re-acquire a lock it already holds without deadlocking,
+// and the lock is released after every acquisition is released.
+// Locks are extended periodically by the ExtendInterval until the returned context is canceled.
+type ReentrantLocker interface {
+	// WithContext acquires a lock by name for the owner by waiting for it.
+	WithContext(ctx context.Context, name, owner string) (context.Context, context.CancelFunc, error)
+	// TryWithContext tries to acquire a lock by name for the owner without waiting. It may return ErrNotLocked.
+	TryWithContext(ctx context.Context, name, owner string) (context.Context, context.CancelFunc, error)
+}
+
+type reentrantLocker struct {
+	*hashLocker
+}
+
+func (l reentrantLocker) WithContext(ctx context.Context, name, owner string) (context.Context, context.CancelFunc, error) {
+	if owner == "" {
+		return ctx, nil, ErrEmptyLockOwner
+	}
+	return l.acquire(ctx, l.key(reentrantLockKeyFormat, name), lockModeWrite, owner, true)
+}
+
+func (l reentrantLocker) TryWithContext(ctx context.Context, name, owner string) (context.Context, context.CancelFunc, error) {
+	if owner == "" {
+		return ctx, nil, ErrEmptyLockOwner
+	}
+	return l.acquire(ctx, l.key(reentrantLockKeyFormat, name), lockModeWrite, owner, false)
+}
+
+// NewReentrantLocker 新建一个可重入锁，使用 LockerOption 中的 KeyPrefix、KeyValidity、ExtendInterval 以及 RetryInterval
+func (c *client) NewReentrantLocker(opts ...LockerOption) (ReentrantLocker, error) {
+	return reentrantLocker{hashLocker: newHashLocker(c, opts...)}, nil
+}
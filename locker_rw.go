@@ -0,0 +1,242 @@
+package redisson
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis/rueidislock"
+)
+
+// hash lock 的每个持有者占用一个字段，值为 "重入次数:过期时间"，mode 字段为当前的锁模式
+// 过期时间使用 Redis 服务器的时间，避免客户端之间的时钟偏差
+var hashLockHoldersLua = `
+if redis.replicate_commands then
+	redis.replicate_commands()
+end
+local function server_now()
+	local t = redis.call('TIME')
+	return tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+end
+local function holders(key, now)
+	local fields = redis.call('HGETALL', key)
+	local n = 0
+	for i = 1, #fields, 2 do
+		if fields[i] ~= 'mode' then
+			local exp = tonumber(string.match(fields[i+1], ':(%d+)$') or '0')
+			if exp <= now then
+				redis.call('HDEL', key, fields[i])
+			else
+				n = n + 1
+			end
+		end
+	end
+	if n == 0 then
+		redis.call('DEL', key)
+	end
+	return n
+end
+local function keepalive(key, ttl)
+	if redis.call('PTTL', key) < ttl then
+		redis.call('PEXPIRE', key, ttl)
+	end
+end
+`
+
+var acquireHashLockLua = hashLockHoldersLua + `
+local key = KEYS[1]
+local mode, token, ttl, now = ARGV[1], ARGV[2], tonumber(ARGV[3]), server_now()
+local n = holders(key, now)
+local current = redis.call('HGET', key, 'mode')
+local held = redis.call('HGET', key, token)
+if held then
+	if mode == 'write' and current ~= 'write' then
+		if n > 1 then
+			return 0
+		end
+		redis.call('HSET', key, 'mode', 'write')
+	end
+	local count = tonumber(string.match(held, '^(%d+):'))
+	redis.call('HSET', key, token, (count + 1) .. ':' .. (now + ttl))
+else
+	if n > 0 and (mode == 'write' or current == 'write') then
+		return 0
+	end
+	redis.call('HSET', key, 'mode', mode)
+	redis.call('HSET', key, token, '1:' .. (now + ttl))
+end
+keepalive(key, ttl)
+return 1
+`
+
+var releaseHashLockLua = hashLockHoldersLua + `
+local key = KEYS[1]
+local token, now = ARGV[1], server_now()
+local held = redis.call('HGET', key, token)
+if not held then
+	return 0
+end
+local count, exp = string.match(held, '^(%d+):(%d+)$')
+count = tonumber(count) - 1
+if count > 0 then
+	redis.call('HSET', key, token, count .. ':' .. exp)
+else
+	redis.call('HDEL', key, token)
+	holders(key, now)
+end
+return 1
+`
+
+var extendHashLockLua = hashLockHoldersLua + `
+local key = KEYS[1]
+local token, ttl, now = ARGV[1], tonumber(ARGV[2]), server_now()
+local held = redis.call('HGET', key, token)
+if not held then
+	return 0
+end
+local exp = tonumber(string.match(held, ':(%d+)$'))
+if exp <= now then
+	redis.call('HDEL', key, token)
+	holders(key, now)
+	return 0
+end
+redis.call('HSET', key, token, string.match(held, '^(%d+):') .. ':' .. (now + ttl))
+keepalive(key, ttl)
+return 1
+`
+
+const (
+	lockModeRead  = "read"
+	lockModeWrite = "write"
+
+	rwLockKeyFormat        = "%s:rw:{%s}"
+	reentrantLockKeyFormat = "%s:re:{%s}"
+)
+
+// ReadWriteLocker is the interface of read-write lock. Many readers or one writer can hold a lock at the same time.
+// Locks are extended periodically by the ExtendInterval until the returned context is canceled.
+type ReadWriteLocker interface {
+	// RLockWithContext acquires a read lock by name by waiting for it.
+	RLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
+	// TryRLockWithContext tries to acquire a read lock by name without waiting. It may return ErrNotLocked.
+	TryRLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
+	// LockWithContext acquires a write lock by name by waiting for it.
+	LockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
+	// TryLockWithContext tries to acquire a write lock by name without waiting. It may return ErrNotLocked.
+	TryLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error)
+}
+
+// hashLocker 基于哈希表实现的锁，只操作单个 key，兼容 Redis 7 以下版本以及集群模式
+type hashLocker struct {
+	c    *client
+	spec LockerOptionsVisitor
+
+	acquireScript Scripter
+	releaseScript Scripter
+	extendScript  Scripter
+}
+
+func newHashLocker(c *client, opts ...LockerOption) *hashLocker {
+	return &hashLocker{
+		c:             c,
+		spec:          newLockerOptions(opts...),
+		acquireScript: c.CreateScript(acquireHashLockLua),
+		releaseScript: c.CreateScript(releaseHashLockLua),
+		extendScript:  c.CreateScript(extendHashLockLua),
+	}
+}
+
+func newLockToken() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (l *hashLocker) key(format, name string) string {
+	return fmt.Sprintf(format, l.spec.GetKeyPrefix(), name)
+}
+
+func (l *hashLocker) acquire(ctx context.Context, key, mode, token string, wait bool) (context.Context, context.CancelFunc, error) {
+	for {
+		n, err := l.acquireScript.Run(ctx, []string{key}, mode, token, l.spec.GetKeyValidity().Milliseconds()).Int64()
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return ctx, nil, err
+		}
+		if n == 1 {
+			ctx0, cancel := l.hold(ctx, key, token)
+			return ctx0, cancel, nil
+		}
+		if !wait {
+			return ctx, nil, rueidislock.ErrNotLocked
+		}
+		select {
+		case <-ctx.Done():
+			return ctx, nil, ctx.Err()
+		case <-time.After(l.spec.GetRetryInterval()):
+		}
+	}
+}
+
+// hold 定期延长锁的有效期，返回的 context 在锁丢失时被取消，调用 cancel 释放锁
+func (l *hashLocker) hold(ctx context.Context, key, token string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(l.spec.GetExtendInterval())
+		defer t.Stop()
+		extended := nowFunc()
+		for {
+			select {
+			case <-ctx.Done():
+				ctx0, cancel0 := context.WithTimeout(context.Background(), l.c.v.GetWriteTimeout())
+				_ = l.releaseScript.Run(ctx0, []string{key}, token).Err()
+				cancel0()
+				return
+			case <-t.C:
+				now := nowFunc()
+				n, err := l.extendScript.Run(ctx, []string{key}, token, l.spec.GetKeyValidity().Milliseconds()).Int64()
+				if err == nil && n == 1 {
+					extended = now
+				} else if err == nil || now.Sub(extended) >= l.spec.GetKeyValidity() {
+					// 锁已丢失
+					cancel()
+				}
+			}
+		}
+	}()
+	return ctx, func() {
+		cancel()
+		<-done
+	}
+}
+
+type rwLocker struct {
+	*hashLocker
+}
+
+func (l rwLocker) RLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error) {
+	return l.acquire(ctx, l.key(rwLockKeyFormat, name), lockModeRead, newLockToken(), true)
+}
+
+func (l rwLocker) TryRLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error) {
+	return l.acquire(ctx, l.key(rwLockKeyFormat, name), lockModeRead, newLockToken(), false)
+}
+
+func (l rwLocker) LockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error) {
+	return l.acquire(ctx, l.key(rwLockKeyFormat, name), lockModeWrite, newLockToken(), true)
+}
+
+func (l rwLocker) TryLockWithContext(ctx context.Context, name string) (context.Context, context.CancelFunc, error) {
+	return l.acquire(ctx, l.key(rwLockKeyFormat, name), lockModeWrite, newLockToken(), false)
+}
+
+// NewReadWriteLocker 新建一个读写锁，使用 LockerOption 中的 KeyPrefix、KeyValidity、ExtendInterval 以及 RetryInterval
+func (c *client) NewReadWriteLocker(opts ...LockerOption) (ReadWriteLocker, error) {
+	return rwLocker{hashLocker: newHashLocker(c, opts...)}, nil
+}
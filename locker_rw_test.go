@@ -0,0 +1,102 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis/rueidislock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHashLocker(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var opts = []LockerOption{
+		WithLockerOptionKeyPrefix("mock_lock"),
+		WithLockerOptionKeyValidity(time.Second),
+		WithLockerOptionExtendInterval(200 * time.Millisecond),
+		WithLockerOptionRetryInterval(10 * time.Millisecond),
+	}
+
+	Convey("read write locker", t, func() {
+		l, err := c.NewReadWriteLocker(opts...)
+		So(err, ShouldBeNil)
+
+		_, r1, err := l.TryRLockWithContext(ctx, "rw")
+		So(err, ShouldBeNil)
+		_, r2, err := l.TryRLockWithContext(ctx, "rw")
+		So(err, ShouldBeNil)
+		_, _, err = l.TryLockWithContext(ctx, "rw")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+
+		// 锁会自动续期
+		time.Sleep(1500 * time.Millisecond)
+		_, _, err = l.TryLockWithContext(ctx, "rw")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+
+		r1()
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			r2()
+		}()
+		var start = time.Now()
+		wctx, w, err := l.LockWithContext(ctx, "rw")
+		So(err, ShouldBeNil)
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+		So(wctx.Err(), ShouldBeNil)
+
+		_, _, err = l.TryRLockWithContext(ctx, "rw")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+		tctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, _, err = l.RLockWithContext(tctx, "rw")
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		w()
+		So(wctx.Err(), ShouldNotBeNil)
+		_, r3, err := l.TryRLockWithContext(ctx, "rw")
+		So(err, ShouldBeNil)
+		r3()
+	})
+
+	Convey("reentrant locker", t, func() {
+		l, err := c.NewReentrantLocker(opts...)
+		So(err, ShouldBeNil)
+		_, _, err = l.TryWithContext(ctx, "re", "")
+		So(err, ShouldEqual, ErrEmptyLockOwner)
+
+		_, c1, err := l.TryWithContext(ctx, "re", "owner")
+		So(err, ShouldBeNil)
+		_, c2, err := l.WithContext(ctx, "re", "owner")
+		So(err, ShouldBeNil)
+		_, _, err = l.TryWithContext(ctx, "re", "other")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+
+		c2()
+		_, _, err = l.TryWithContext(ctx, "re", "other")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+		c1()
+		_, c3, err := l.TryWithContext(ctx, "re", "other")
+		So(err, ShouldBeNil)
+		c3()
+	})
+
+	Convey("lost lock", t, func() {
+		l, err := c.NewReentrantLocker(opts...)
+		So(err, ShouldBeNil)
+		lctx, cancel, err := l.TryWithContext(ctx, "lost", "owner")
+		So(err, ShouldBeNil)
+		defer cancel()
+		So(c.Del(ctx, "mock_lock:re:{lost}").Err(), ShouldBeNil)
+		select {
+		case <-lctx.Done():
+		case <-time.After(time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package redisson
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis/rueidislock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLockerLease(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("lease fencing token", t, func() {
+		l, err := c.NewLocker(WithLockerOptionKeyPrefix("mock_lease"), WithLockerOptionHolderID("holder"))
+		So(err, ShouldBeNil)
+
+		_, cancel1, lease1, err := l.TryLeaseWithContext(ctx, "job:1")
+		So(err, ShouldBeNil)
+		So(lease1.Name, ShouldEqual, "job:1")
+		So(lease1.Holder, ShouldEqual, "holder")
+		So(lease1.ExpiresAt.After(time.Now()), ShouldBeTrue)
+
+		_, _, _, err = l.TryLeaseWithContext(ctx, "job:1")
+		So(err, ShouldWrap, rueidislock.ErrNotLocked)
+
+		held, err := l.Inspect(ctx, "job:1")
+		So(err, ShouldBeNil)
+		So(held.Holder, ShouldEqual, "holder")
+		So(held.Token, ShouldEqual, lease1.Token)
+
+		_, cancel2, lease2, err := l.TryLeaseWithContext(ctx, "job:2")
+		So(err, ShouldBeNil)
+		leases, err := l.ListHeld(ctx, "job:")
+		So(err, ShouldBeNil)
+		So(len(leases), ShouldEqual, 2)
+		cancel2()
+		So(lease2.Token, ShouldBeGreaterThan, 0)
+
+		cancel1()
+		_, err = l.Inspect(ctx, "job:1")
+		So(err, ShouldEqual, ErrLockNotHeld)
+		leases, err = l.ListHeld(ctx, "job:")
+		So(err, ShouldBeNil)
+		So(len(leases), ShouldEqual, 0)
+
+		_, cancel3, lease3, err := l.TryLeaseWithContext(ctx, "job:1")
+		So(err, ShouldBeNil)
+		So(lease3.Token, ShouldBeGreaterThan, lease1.Token)
+		cancel3()
+	})
+
+	Convey("lease lost", t, func() {
+		l, err := c.NewLocker(WithLockerOptionKeyPrefix("mock_lease"))
+		So(err, ShouldBeNil)
+		lctx, cancel, lease, err := l.TryLeaseWithContext(ctx, "lost")
+		So(err, ShouldBeNil)
+		defer cancel()
+		for _, key := range []string{"mock_lease:0:lost", "mock_lease:1:lost", "mock_lease:2:lost"} {
+			So(c.Del(ctx, key).Err(), ShouldBeNil)
+		}
+		select {
+		case <-lctx.Done():
+		case <-time.After(3 * time.Second):
+			So("timeout", ShouldBeEmpty)
+		}
+		time.Sleep(100 * time.Millisecond)
+		holder, err := c.HGet(ctx, "mock_lease:lease:lost", "holder").Result()
+		So(IsNil(err), ShouldBeTrue)
+		So(holder, ShouldBeEmpty)
+		So(lease.Token, ShouldBeGreaterThan, 0)
+	})
+
+	Convey("lease held by stale holder", t, func() {
+		l, err := c.NewLocker(WithLockerOptionKeyPrefix("mock_lease"), WithLockerOptionKeyValidity(time.Second))
+		So(err, ShouldBeNil)
+		// 不使用 Lease 的方法不记录持有信息
+		_, cancel, err := l.TryWithContext(ctx, "stale")
+		So(err, ShouldBeNil)
+		So(c.HExists(ctx, "mock_lease:lease:stale", "holder").Val(), ShouldBeFalse)
+		cancel()
+
+		// 其他持有者的租约未过期时，不能获得新的 fencing token
+		So(c.HSet(ctx, "mock_lease:lease:stale", "holder", "stale", "fence", "1", "expire", time.Now().Add(time.Second).UnixMilli()).Err(), ShouldBeNil)
+		_, _, _, err = l.TryLeaseWithContext(ctx, "stale")
+		So(err, ShouldEqual, rueidislock.ErrNotLocked)
+		_, cancel, lease, err := l.LeaseWithContext(ctx, "stale")
+		So(err, ShouldBeNil)
+		So(lease.Token, ShouldBeGreaterThan, 1)
+		cancel()
+	})
+}
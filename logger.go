@@ -0,0 +1,119 @@
+package redisson
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// Level 日志级别
+type Level int8
+
+const (
+	LevelDebug Level = iota - 1
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Field 结构化日志字段
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Any 新建一个结构化日志字段
+func Any(key string, value any) Field { return Field{Key: key, Value: value} }
+
+const (
+	fieldQueue   = "queue"
+	fieldCommand = "command"
+	fieldError   = "error"
+	fieldData    = "data"
+)
+
+func queueField(name string) Field { return Any(fieldQueue, name) }
+func commandField(c Command) Field { return Any(fieldCommand, c.String()) }
+func errorField(err error) Field   { return Any(fieldError, err) }
+func dataField(data []byte) Field  { return Any(fieldData, string(data)) }
+
+// Logger 日志接口，可以通过 WithLogger 进行设置
+// 默认使用 log/slog 输出至标准输出
+type Logger interface {
+	// Log 输出日志
+	Log(level Level, msg string, fields ...Field)
+}
+
+type slogLogger struct{ l *slog.Logger }
+
+// NewSlogLogger 使用 log/slog 输出日志
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) Log(level Level, msg string, fields ...Field) {
+	var sl slog.Level
+	switch level {
+	case LevelDebug:
+		sl = slog.LevelDebug
+	case LevelInfo:
+		sl = slog.LevelInfo
+	case LevelWarn:
+		sl = slog.LevelWarn
+	default:
+		sl = slog.LevelError
+	}
+	ctx := context.Background()
+	if !s.l.Enabled(ctx, sl) {
+		return
+	}
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	s.l.LogAttrs(ctx, sl, msg, attrs...)
+}
+
+type nopLogger struct{}
+
+// NopLogger 不输出任何日志
+var NopLogger Logger = nopLogger{}
+
+func (nopLogger) Log(Level, string, ...Field) {}
+
+var defaultLogger = NewSlogLogger(slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+func getLogger(v ConfVisitor) Logger {
+	if v != nil {
+		if l := v.GetLogger(); l != nil {
+			return l
+		}
+	}
+	return defaultLogger
+}
+
+func warning(v ConfVisitor, msg string, fields ...Field) {
+	getLogger(v).Log(LevelWarn, msg, fields...)
+}
+
+func e(v ConfVisitor, msg string, fields ...Field) {
+	getLogger(v).Log(LevelError, msg, fields...)
+}
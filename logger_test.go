@@ -0,0 +1,71 @@
+package redisson
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type recordLogger struct {
+	levels []Level
+	msgs   []string
+	fields [][]Field
+}
+
+func (r *recordLogger) Log(level Level, msg string, fields ...Field) {
+	r.levels = append(r.levels, level)
+	r.msgs = append(r.msgs, msg)
+	r.fields = append(r.fields, fields)
+}
+
+func TestLogger(t *testing.T) {
+	Convey("slog adapter", t, func() {
+		var buf bytes.Buffer
+		l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+		l.Log(LevelDebug, "debug message")
+		So(buf.String(), ShouldBeEmpty)
+
+		l.Log(LevelWarn, "warn message", Any("queue", "q1"), errorField(errors.New("boom")))
+		So(buf.String(), ShouldContainSubstring, "level=WARN")
+		So(buf.String(), ShouldContainSubstring, `msg="warn message"`)
+		So(buf.String(), ShouldContainSubstring, "queue=q1")
+		So(buf.String(), ShouldContainSubstring, "error=boom")
+
+		buf.Reset()
+		l.Log(LevelError, "error message")
+		So(buf.String(), ShouldContainSubstring, "level=ERROR")
+		buf.Reset()
+		l.Log(LevelInfo, "info message")
+		So(buf.String(), ShouldContainSubstring, "level=INFO")
+
+		So(NewSlogLogger(nil), ShouldNotBeNil)
+	})
+
+	Convey("level string", t, func() {
+		So(LevelDebug.String(), ShouldEqual, "DEBUG")
+		So(LevelInfo.String(), ShouldEqual, "INFO")
+		So(LevelWarn.String(), ShouldEqual, "WARN")
+		So(LevelError.String(), ShouldEqual, "ERROR")
+		So(Level(10).String(), ShouldEqual, "UNKNOWN")
+	})
+
+	Convey("default and configured logger", t, func() {
+		So(getLogger(nil) == defaultLogger, ShouldBeTrue)
+		So(getLogger(NewConf()) == defaultLogger, ShouldBeTrue)
+		So(getLogger(NewConf(WithLogger(NopLogger))) == NopLogger, ShouldBeTrue)
+		// NopLogger 不输出任何内容，也不会 panic
+		So(func() { warning(NewConf(WithLogger(NopLogger)), "nothing") }, ShouldNotPanic)
+
+		r := &recordLogger{}
+		v := NewConf(WithLogger(r))
+		warning(v, "warn", Any("k", "v"))
+		e(v, "error")
+		So(r.levels, ShouldResemble, []Level{LevelWarn, LevelError})
+		So(r.msgs, ShouldResemble, []string{"warn", "error"})
+		So(r.fields[0], ShouldResemble, []Field{{Key: "k", Value: "v"}})
+	})
+}
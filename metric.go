@@ -14,6 +14,18 @@ const (
 	delayPollErrorMetricName    = "redis_delay_poll_error"
 	delayReclaimErrorMetricName = "redis_delay_reclaim_error"
 	delayReclaimCountMetricName = "redis_delay_reclaim"
+	delayInflightMetricName     = "redis_delay_inflight"
+	delayProcessedMetricName    = "redis_delay_processed"
+	delayLagMetricName          = "redis_delay_schedule_lag_seconds"
+	delayDurationMetricName     = "redis_delay_callback_duration_seconds"
+	semaphoreAcquireMetricName  = "redis_semaphore_acquire"
+	semaphoreWaitMetricName     = "redis_semaphore_wait_seconds"
+	lockWaitMetricName          = "redis_lock_wait_seconds"
+	lockLostMetricName          = "redis_lock_lost"
+	rateLimitDecisionMetricName = "redis_rate_limit_decision"
+	pubSubStateMetricName       = "redis_pubsub_state"
+	pubSubReconnectMetricName   = "redis_pubsub_reconnect"
+	pubSubDroppedMetricName     = "redis_pubsub_dropped"
 )
 
 var (
@@ -21,11 +33,29 @@ var (
 	metric                                                                 *prometheus.SummaryVec
 	errMetric, hitsMetric, missMetric                                      *prometheus.CounterVec
 	delayPollErrorMetric, delayReclaimErrorMetric, delayReclaimCountMetric *prometheus.CounterVec
+	delayProcessedMetric                                                   *prometheus.CounterVec
+	delayInflightMetric                                                    *prometheus.GaugeVec
+	delayLagMetric, delayDurationMetric                                    *prometheus.HistogramVec
+	semaphoreAcquireMetric                                                 *prometheus.CounterVec
+	semaphoreWaitMetric                                                    *prometheus.HistogramVec
+	lockWaitMetric                                                         *prometheus.HistogramVec
+	lockLostMetric                                                         *prometheus.CounterVec
+	rateLimitDecisionMetric                                                *prometheus.CounterVec
+	pubSubStateMetric                                                      *prometheus.GaugeVec
+	pubSubReconnectMetric, pubSubDroppedMetric                             *prometheus.CounterVec
 )
 
 var (
-	labelKeys      = []string{"command", "s_command"}
-	queueLabelKeys = []string{"queue"}
+	labelKeys                = []string{"command", "s_command"}
+	queueLabelKeys           = []string{"queue"}
+	queueResultLabelKeys     = []string{"queue", "result"}
+	semaphoreLabelKeys       = []string{"semaphore"}
+	semaphoreResultLabelKeys = []string{"semaphore", "result"}
+	lockerLabelKeys          = []string{"locker"}
+	rateLimitLabelKeys       = []string{"limiter", "source", "result"}
+	stateLabelKeys           = []string{"state"}
+	resultLabelKeys          = []string{"result"}
+	reasonLabelKeys          = []string{"reason"}
 )
 
 func init() {
@@ -47,6 +77,53 @@ func init() {
 	delayReclaimCountMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: delayReclaimCountMetricName,
 	}, queueLabelKeys)
+	delayInflightMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: delayInflightMetricName,
+	}, queueLabelKeys)
+	delayProcessedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: delayProcessedMetricName,
+	}, queueResultLabelKeys)
+	delayLagMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    delayLagMetricName,
+		Help:    "actual fire time minus due time of delay tasks.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, queueLabelKeys)
+	delayDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    delayDurationMetricName,
+		Help:    "callback duration of delay tasks.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, queueLabelKeys)
+	semaphoreAcquireMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: semaphoreAcquireMetricName,
+	}, semaphoreResultLabelKeys)
+	semaphoreWaitMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    semaphoreWaitMetricName,
+		Help:    "wait duration of acquiring semaphore permits.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, semaphoreLabelKeys)
+	lockWaitMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    lockWaitMetricName,
+		Help:    "wait duration of acquiring locks.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, lockerLabelKeys)
+	lockLostMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: lockLostMetricName,
+	}, lockerLabelKeys)
+	rateLimitDecisionMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: rateLimitDecisionMetricName,
+		Help: "rate limit decisions made locally from leased tokens or remotely by redis.",
+	}, rateLimitLabelKeys)
+	pubSubStateMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: pubSubStateMetricName,
+		Help: "number of pubsub subscribers in each state.",
+	}, stateLabelKeys)
+	pubSubReconnectMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: pubSubReconnectMetricName,
+	}, resultLabelKeys)
+	pubSubDroppedMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: pubSubDroppedMetricName,
+		Help: "pubsub messages dropped because the channel is full.",
+	}, reasonLabelKeys)
 	metric = prometheus.NewSummaryVec(prometheus.SummaryOpts{
 		Name:       timingMetricName,
 		Objectives: map[float64]float64{0.5: 0.05, 0.95: 0.02, 0.99: 0.001, 1: 0},
@@ -62,6 +139,18 @@ func registerMetric(rc RegisterCollectorFunc) {
 		rc(delayPollErrorMetric)
 		rc(delayReclaimErrorMetric)
 		rc(delayReclaimCountMetric)
+		rc(delayInflightMetric)
+		rc(delayProcessedMetric)
+		rc(delayLagMetric)
+		rc(delayDurationMetric)
+		rc(semaphoreAcquireMetric)
+		rc(semaphoreWaitMetric)
+		rc(lockWaitMetric)
+		rc(lockLostMetric)
+		rc(rateLimitDecisionMetric)
+		rc(pubSubStateMetric)
+		rc(pubSubReconnectMetric)
+		rc(pubSubDroppedMetric)
 		rc(metric)
 	})
 }
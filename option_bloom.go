@@ -5,5 +5,9 @@ func BloomOptionsOptionDeclareWithDefault() any {
 	return map[string]any{
 		// annotation@KeyPrefix(If enabled, Exists and ExistsMulti methods will be available as read-only operations. NOTE: If enabled, minimum redis version should be 7.0.0.)
 		"EnableReadOperation": false,
+		// annotation@GrowthFactor(comment="可扩展布隆过滤器每一层的容量相对上一层的倍数")
+		"GrowthFactor": uint(2),
+		// annotation@TighteningRatio(comment="可扩展布隆过滤器每一层的误判率相对上一层的比例，取值范围 (0, 1)")
+		"TighteningRatio": 0.5,
 	}
 }
@@ -0,0 +1,13 @@
+package redisson
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newCuckooOptions --empty_composite_nil=true --usage_tag_name=usage
+func CuckooOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@EnableReadOperation(comment="Exists 以及 ExistsMulti 使用只读脚本，需要 Redis 7.0.0 及以上版本")
+		"EnableReadOperation": false,
+		// annotation@BucketSize(comment="每个桶中指纹的数量")
+		"BucketSize": uint(4),
+		// annotation@MaxKicks(comment="插入时最多踢出的次数，超过后认为过滤器已满")
+		"MaxKicks": uint(500),
+	}
+}
@@ -1,7 +1,6 @@
 package redisson
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -12,9 +11,25 @@ func DelayOptionsOptionDeclareWithDefault() any {
 		"Prefix": "",
 		// annotation@Timeout(业务处理超时时间，如果超过该时间未处理，则重试)
 		"Timeout": time.Duration(1 * time.Minute),
+		// annotation@PollInterval(comment="最长轮询间隔，轮询协程会休眠至下一个任务的到期时间，但不超过该间隔")
+		"PollInterval": time.Duration(time.Second),
+		// annotation@Workers(comment="并发处理任务的协程数")
+		"Workers": 1,
+		// annotation@BatchSize(comment="每次轮询最多取出的到期任务数量")
+		"BatchSize": int64(100),
+		// annotation@Prefetch(comment="已取出待处理任务的缓冲区大小，缓冲区中的任务同样受 Timeout 限制")
+		"Prefetch": 16,
+		// annotation@DrainTimeout(comment="关闭队列时，等待已取出任务处理完成的最长时间")
+		"DrainTimeout": time.Duration(10 * time.Second),
 		// annotation@RetryTimes(comment="重试次数，当业务处理超时，或业务处理返回错误，则重试")
 		"RetryTimes": 3,
-		// annotation@HandleDeadLetter(comment="处理死信，当达到最大重试次数，则为死信")
-		"HandleDeadLetter": func(bs []byte) { warning(fmt.Sprintf("got dead letter, %v", bs)) },
+		// annotation@RetryPolicy(comment="重试策略，业务处理返回错误后，根据失败次数计算重试的延迟时间，为 nil 时立即重试")
+		"RetryPolicy": RetryPolicy(nil),
+		// annotation@HandleMessage(comment="处理消息，设置后代替 NewDelayQueue 的回调，可以获取消息 ID、消息头以及投递次数")
+		"HandleMessage": (func(m *DelayMessage) error)(nil),
+		// annotation@HandleDeadLetter(comment="处理死信，当达到最大重试次数，则为死信，未设置时输出警告日志")
+		"HandleDeadLetter": (func(bs []byte))(nil),
+		// annotation@DeadLetterMaxLen(comment="保留的死信最大数量，可以通过 ListDeadLetters 查看")
+		"DeadLetterMaxLen": int64(10000),
 	}
 }
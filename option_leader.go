@@ -0,0 +1,19 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newLeaderOptions --empty_composite_nil=true --usage_tag_name=usage
+func LeaderOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@KeyPrefix(comment="选举使用的锁前缀")
+		"KeyPrefix": "redisleader",
+		// annotation@ID(comment="参与选举的实例 ID，为空时根据 hostname 以及 pid 生成")
+		"ID": "",
+		// annotation@KeyValidity(comment="领导者的租期，领导者崩溃后，其他实例最长在该时间后接替")
+		"KeyValidity": time.Duration(5 * time.Second),
+		// annotation@RetryInterval(comment="未收到领导者变更通知时，重新竞选的间隔")
+		"RetryInterval": time.Duration(5 * time.Second),
+	}
+}
@@ -8,6 +8,7 @@ const (
 	defaultExtendInterval = 1 * time.Second
 	defaultTryNextAfter   = 20 * time.Millisecond
 	defaultKeyMajority    = int32(2)
+	defaultRetryInterval  = 50 * time.Millisecond
 )
 
 //go:generate optiongen --option_with_struct_name=true --new_func=newLockerOptions --empty_composite_nil=true --usage_tag_name=usage
@@ -25,5 +26,11 @@ func LockerOptionsOptionDeclareWithDefault() any {
 		"NoLoopTracking": false,
 		// annotation@FallbackSETPX(Use SET PX instead of SET PXAT when acquiring locks to be compatible with Redis < 6.2)
 		"FallbackSETPX": false,
+		// annotation@ExtendInterval(ExtendInterval is the interval of extending locks acquired by ReadWriteLocker and ReentrantLocker, and Leases acquired by Locker. Default value is defaultExtendInterval)
+		"ExtendInterval": time.Duration(defaultExtendInterval),
+		// annotation@RetryInterval(RetryInterval is the interval of retrying when ReadWriteLocker and ReentrantLocker wait for locks, and Locker waits for the Lease of a previous holder to expire. Default value is defaultRetryInterval)
+		"RetryInterval": time.Duration(defaultRetryInterval),
+		// annotation@HolderID(HolderID is the holder ID recorded in the Lease of locks acquired by Locker. Default value is generated from the hostname and pid)
+		"HolderID": "",
 	}
 }
@@ -0,0 +1,29 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newPubSubOptions --empty_composite_nil=true --usage_tag_name=usage
+func PubSubOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@ChannelSize(comment="消息缓冲区大小")
+		"ChannelSize": 100,
+		// annotation@OverflowPolicy(comment="消息缓冲区已满时的处理策略")
+		"OverflowPolicy": PubSubOverflowPolicy(PubSubOverflowBlock),
+		// annotation@SendTimeout(comment="OverflowPolicy 为 PubSubOverflowBlock 时，等待消息缓冲区的最长时间，超时后丢弃消息，为 0 时一直等待")
+		"SendTimeout": time.Duration(0),
+		// annotation@OnOverflow(comment="OverflowPolicy 为 PubSubOverflowCallback 时，消息缓冲区已满时处理消息，回调中不能阻塞")
+		"OnOverflow": (func(m Message))(nil),
+		// annotation@RetryPolicy(comment="连接断开后重新订阅的退避策略，重试间隔不小于 50ms，为 nil 时以 50ms 的间隔重试")
+		"RetryPolicy": RetryPolicy(ExponentialBackoff(100*time.Millisecond, 10*time.Second, 0.2)),
+		// annotation@MaxRetries(comment="连接断开后连续重新订阅失败的最大次数，超过后订阅结束，为 0 时不限制")
+		"MaxRetries": 0,
+		// annotation@OnSubscribed(comment="收到订阅成功的确认时回调，包括重新订阅")
+		"OnSubscribed": (func(s Subscription))(nil),
+		// annotation@OnDisconnected(comment="连接断开时回调")
+		"OnDisconnected": (func(err error))(nil),
+		// annotation@OnResubscribed(comment="连接断开后重新订阅成功时回调，attempts 为尝试的次数")
+		"OnResubscribed": (func(attempts int))(nil),
+	}
+}
@@ -0,0 +1,23 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newRateLimiterOptions --empty_composite_nil=true --usage_tag_name=usage
+func RateLimiterOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@Prefix(限流器前缀)
+		"Prefix": "",
+		// annotation@Algorithm(comment="限流算法")
+		"Algorithm": RateLimitAlgorithm(RateLimitGCRA),
+		// annotation@Burst(comment="GCRA 算法允许的突发数量，小于等于 0 时与 limit 相同")
+		"Burst": int64(0),
+		// annotation@SameSlot(comment="组合限流器的所有 key 是否使用相同的 hash tag，为 false 时集群模式下按 slot 分组执行，无法保证原子性")
+		"SameSlot": true,
+		// annotation@LocalBatch(comment="每次从 Redis 租借的配额数量，大于 1 时开启本地批量模式，越大吞吐越高但精度越低，超过 Limit（GCRA 为 Burst）时按其限制")
+		"LocalBatch": int64(0),
+		// annotation@LocalLeaseTTL(comment="本地租借配额的有效期，过期未用完的配额将被丢弃")
+		"LocalLeaseTTL": time.Duration(500 * time.Millisecond),
+	}
+}
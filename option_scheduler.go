@@ -0,0 +1,21 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newSchedulerOptions --empty_composite_nil=true --usage_tag_name=usage
+func SchedulerOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@Prefix(调度器前缀)
+		"Prefix": "",
+		// annotation@PollInterval(comment="最长轮询间隔，调度器会休眠至下一个任务的执行时间，但不超过该间隔")
+		"PollInterval": time.Duration(time.Second),
+		// annotation@MisfirePolicy(comment="错过执行时间后的处理策略")
+		"MisfirePolicy": MisfirePolicy(MisfireFireOnce),
+		// annotation@MisfireThreshold(comment="MisfireSkip 策略下，超过该时间的执行会被跳过")
+		"MisfireThreshold": time.Duration(time.Minute),
+		// annotation@Location(comment="cron 表达式使用的时区，为 nil 时使用 time.Local")
+		"Location": (*time.Location)(nil),
+	}
+}
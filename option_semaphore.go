@@ -0,0 +1,17 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newSemaphoreOptions --empty_composite_nil=true --usage_tag_name=usage
+func SemaphoreOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@Prefix(信号量前缀)
+		"Prefix": "",
+		// annotation@LeaseTTL(comment="许可的租期，持有者未在租期内 Refresh 或 Release，许可会被自动回收")
+		"LeaseTTL": time.Duration(30 * time.Second),
+		// annotation@RetryInterval(comment="Acquire 等待许可时的重试间隔")
+		"RetryInterval": time.Duration(50 * time.Millisecond),
+	}
+}
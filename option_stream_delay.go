@@ -0,0 +1,29 @@
+package redisson
+
+import (
+	"time"
+)
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newStreamDelayOptions --empty_composite_nil=true --usage_tag_name=usage
+func StreamDelayOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@Prefix(延迟队列前缀)
+		"Prefix": "",
+		// annotation@Group(comment="消费组名字")
+		"Group": "redisson",
+		// annotation@Consumer(comment="消费者名字，为空时自动生成")
+		"Consumer": "",
+		// annotation@Workers(comment="并发处理任务的协程数")
+		"Workers": 4,
+		// annotation@PollInterval(comment="将到期任务移入 Stream 的轮询间隔")
+		"PollInterval": time.Duration(time.Second),
+		// annotation@Timeout(comment="业务处理超时时间，如果超过该时间未确认，则被其他消费者通过 XAUTOCLAIM 认领")
+		"Timeout": time.Duration(1 * time.Minute),
+		// annotation@RetryTimes(comment="重试次数，当业务处理返回错误，则重试")
+		"RetryTimes": 3,
+		// annotation@RetryDelay(comment="业务处理返回错误后，重试的延迟时间")
+		"RetryDelay": time.Duration(time.Second),
+		// annotation@DeadLetterMaxLen(comment="死信 Stream 的最大长度")
+		"DeadLetterMaxLen": int64(10000),
+	}
+}
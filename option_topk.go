@@ -0,0 +1,11 @@
+package redisson
+
+//go:generate optiongen --option_with_struct_name=true --new_func=newTopKOptions --empty_composite_nil=true --usage_tag_name=usage
+func TopKOptionsOptionDeclareWithDefault() any {
+	return map[string]any{
+		// annotation@Width(comment="计数使用的 Count-Min Sketch 的宽度，为 0 时使用 k * 8，且不小于 256")
+		"Width": uint(0),
+		// annotation@Depth(comment="计数使用的 Count-Min Sketch 的深度")
+		"Depth": uint(5),
+	}
+}
@@ -0,0 +1,334 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// 限流脚本均返回 {是否允许, 剩余配额, 重试等待毫秒数, 配额完全恢复的毫秒数}，重试等待为 -1 表示请求数量超过上限，永远不会被允许
+
+var fixedWindowRateLimitLua = `
+local key = KEYS[1]
+local limit, n, reset = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local count = tonumber(redis.call('GET', key) or '0')
+if count + n > limit then
+	local retry = reset
+	if n > limit then
+		retry = -1
+	end
+	return {0, limit - count, retry, reset}
+end
+count = redis.call('INCRBY', key, n)
+if redis.call('PTTL', key) < 0 then
+	redis.call('PEXPIRE', key, reset)
+end
+return {1, limit - count, 0, reset}
+`
+
+var slidingLogRateLimitLua = `
+local key = KEYS[1]
+local limit, n, period, now, id = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4]), ARGV[5]
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - period)
+local count = redis.call('ZCARD', key)
+if count + n > limit then
+	local retry, reset = -1, 0
+	if n <= limit then
+		local oldest = redis.call('ZRANGE', key, count + n - limit - 1, count + n - limit - 1, 'WITHSCORES')
+		retry = tonumber(oldest[2]) + period - now
+	end
+	local latest = redis.call('ZRANGE', key, -1, -1, 'WITHSCORES')
+	if latest[2] then
+		reset = tonumber(latest[2]) + period - now
+	end
+	return {0, limit - count, retry, reset}
+end
+for i = 1, n do
+	redis.call('ZADD', key, now, id .. ':' .. i)
+end
+redis.call('PEXPIRE', key, period)
+return {1, limit - count - n, 0, period}
+`
+
+var slidingWindowRateLimitLua = `
+local current_key, previous_key = KEYS[1], KEYS[2]
+local limit, n, period, elapsed = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4])
+local current = tonumber(redis.call('GET', current_key) or '0')
+local previous = tonumber(redis.call('GET', previous_key) or '0')
+local estimated = previous * (period - elapsed) / period + current
+if estimated + n > limit then
+	local retry = period - elapsed
+	if n > limit then
+		retry = -1
+	elseif previous > 0 and current + n <= limit then
+		-- 上一个窗口的权重线性减少，计算估算值降至可用的时间
+		retry = math.ceil((period - elapsed) - (limit - current - n) * period / previous)
+	end
+	local reset = period - elapsed
+	if current > 0 then
+		reset = reset + period
+	end
+	return {0, math.floor(limit - estimated), retry, reset}
+end
+redis.call('INCRBY', current_key, n)
+if redis.call('PTTL', current_key) < 0 then
+	redis.call('PEXPIRE', current_key, 2 * period - elapsed)
+end
+return {1, math.floor(limit - estimated - n), 0, 2 * period - elapsed}
+`
+
+var gcraRateLimitLua = `
+local key = KEYS[1]
+local interval, tolerance, n, now, max_delay = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4]), tonumber(ARGV[5])
+local function remaining(t)
+	-- 加上一个很小的值以避免浮点误差
+	return math.max(0, math.floor((now - t + tolerance) / interval + 1e-6))
+end
+local tat = tonumber(redis.call('GET', key) or '0')
+if tat < now then
+	tat = now
+end
+local increment = interval * n
+if increment > tolerance + 1e-6 then
+	return {0, remaining(tat), -1, math.ceil(tat - now)}
+end
+local new_tat = tat + increment
+local delay = new_tat - tolerance - now
+-- 时钟精度为毫秒，小于 1 毫秒的等待视为无需等待，避免浮点误差导致误判
+if delay < 1 then
+	delay = 0
+end
+if delay > max_delay then
+	return {0, remaining(tat), math.ceil(delay), math.ceil(tat - now)}
+end
+redis.call('SET', key, string.format('%.3f', new_tat), 'PX', math.ceil(new_tat - now))
+return {1, remaining(new_tat), math.ceil(delay), math.ceil(new_tat - now)}
+`
+
+var (
+	ErrEmptyRateLimiterName     = errors.New("rate limiter name cannot be empty")
+	ErrInvalidRateLimit         = errors.New("rate limit and period must be positive")
+	ErrRateLimitExceedsCapacity = errors.New("rate limit n exceeds capacity")
+	ErrRateLimitExceedsDeadline = errors.New("rate limit wait would exceed context deadline")
+)
+
+const (
+	rateLimiterKeyFormat = "rl:{%s:%s}"
+
+	// 限流决策的来源，local 表示使用本地租借的配额
+	rateLimitSourceLocal  = "local"
+	rateLimitSourceRemote = "remote"
+
+	rateLimitResultAllowed  = "allowed"
+	rateLimitResultRejected = "rejected"
+)
+
+// RateLimitAlgorithm 限流算法
+type RateLimitAlgorithm int
+
+const (
+	// RateLimitGCRA 通用信元速率算法，平滑限流并允许 Burst 数量的突发，只占用一个 key
+	RateLimitGCRA RateLimitAlgorithm = iota
+	// RateLimitFixedWindow 固定窗口，窗口边界处可能出现两倍的突发
+	RateLimitFixedWindow
+	// RateLimitSlidingLog 滑动日志，精确但每个请求占用一个有序集合成员
+	RateLimitSlidingLog
+	// RateLimitSlidingWindow 滑动窗口计数，根据上一个窗口的计数加权估算
+	RateLimitSlidingWindow
+)
+
+// RateLimitResult 限流结果，可用于设置 X-RateLimit-* 以及 Retry-After 响应头
+type RateLimitResult struct {
+	// Allowed 是否允许
+	Allowed bool
+	// Limit 周期内的配额
+	Limit int64
+	// Remaining 剩余配额
+	Remaining int64
+	// RetryAfter 未被允许时，需要等待的时间，为 -1 表示请求数量超过上限，永远不会被允许
+	// Reserve 预留成功时，为执行前需要等待的时间
+	RetryAfter time.Duration
+	// ResetAfter 配额完全恢复需要的时间
+	ResetAfter time.Duration
+}
+
+// RateLimiter 分布式限流器，key 为限流的维度，如用户 ID、IP 等
+type RateLimiter interface {
+	// Allow 等同于 AllowN(ctx, key, 1)
+	Allow(ctx context.Context, key string) (*RateLimitResult, error)
+	// AllowN 判断是否允许 n 个请求，允许时扣除配额
+	AllowN(ctx context.Context, key string, n int64) (*RateLimitResult, error)
+	// Reserve 预留 n 个配额，预留成功时 Allowed 为 true，调用者需要等待 RetryAfter 后再执行
+	// 只有 GCRA 算法能够预留未来的配额，其他算法与 AllowN 相同
+	Reserve(ctx context.Context, key string, n int64) (*RateLimitResult, error)
+	// Wait 等待直到允许 n 个请求，等待时间超过 ctx 的超时时间时返回 ErrRateLimitExceedsDeadline
+	Wait(ctx context.Context, key string, n int64) error
+}
+
+type rateLimiter struct {
+	c      *client
+	spec   RateLimiterOptionsVisitor
+	name   string
+	limit  int64
+	period time.Duration
+	script Scripter
+}
+
+func newRateLimiter(c *client, name string, limit int64, period time.Duration, opts ...RateLimiterOption) (*rateLimiter, error) {
+	if name == "" {
+		return nil, ErrEmptyRateLimiterName
+	}
+	if limit <= 0 || period < time.Millisecond {
+		return nil, ErrInvalidRateLimit
+	}
+	spec := newRateLimiterOptions(opts...)
+	l := &rateLimiter{c: c, spec: spec, name: name, limit: limit, period: period}
+	switch spec.GetAlgorithm() {
+	case RateLimitFixedWindow:
+		l.script = c.CreateScript(fixedWindowRateLimitLua)
+	case RateLimitSlidingLog:
+		l.script = c.CreateScript(slidingLogRateLimitLua)
+	case RateLimitSlidingWindow:
+		l.script = c.CreateScript(slidingWindowRateLimitLua)
+	default:
+		l.script = c.CreateScript(gcraRateLimitLua)
+	}
+	return l, nil
+}
+
+func (l *rateLimiter) key(key string) string {
+	k := fmt.Sprintf(rateLimiterKeyFormat, l.name, key)
+	if prefix := l.spec.GetPrefix(); prefix != "" {
+		k = fmt.Sprintf("%s:%s", prefix, k)
+	}
+	return k
+}
+
+func (l *rateLimiter) burst() int64 {
+	if burst := l.spec.GetBurst(); burst > 0 {
+		return burst
+	}
+	return l.limit
+}
+
+// run 执行限流脚本，maxDelay 为 GCRA 算法允许预留的最长等待时间
+func (l *rateLimiter) run(ctx context.Context, key string, n int64, maxDelay time.Duration) (*RateLimitResult, error) {
+	now := nowFunc().UnixMilli()
+	period := l.period.Milliseconds()
+	k := l.key(key)
+	var cmd Cmd
+	switch l.spec.GetAlgorithm() {
+	case RateLimitFixedWindow:
+		window := now / period
+		cmd = l.script.Run(ctx, []string{k + ":" + strconv.FormatInt(window, 10)}, l.limit, n, (window+1)*period-now)
+	case RateLimitSlidingLog:
+		cmd = l.script.Run(ctx, []string{k}, l.limit, n, period, now, newLockToken())
+	case RateLimitSlidingWindow:
+		window := now / period
+		cmd = l.script.Run(ctx, []string{k + ":" + strconv.FormatInt(window, 10), k + ":" + strconv.FormatInt(window-1, 10)}, l.limit, n, period, now-window*period)
+	default:
+		interval := float64(l.period) / float64(time.Millisecond) / float64(l.limit)
+		tolerance := interval * float64(l.burst())
+		cmd = l.script.Run(ctx, []string{k}, interval, tolerance, n, now, maxDelay.Milliseconds())
+	}
+	res, err := cmd.Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 4 {
+		return nil, fmt.Errorf("unexpected rate limit result %v", res)
+	}
+	r := &RateLimitResult{
+		Allowed:    res[0] == 1,
+		Limit:      l.limit,
+		Remaining:  res[1],
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+		ResetAfter: time.Duration(res[3]) * time.Millisecond,
+	}
+	if res[2] < 0 {
+		r.RetryAfter = -1
+	}
+	if r.Remaining < 0 {
+		r.Remaining = 0
+	}
+	return r, nil
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, key string) (*RateLimitResult, error) {
+	return l.AllowN(ctx, key, 1)
+}
+
+func (l *rateLimiter) AllowN(ctx context.Context, key string, n int64) (*RateLimitResult, error) {
+	r, err := l.run(ctx, key, n, 0)
+	if err == nil {
+		l.decision(rateLimitSourceRemote, r.Allowed)
+	}
+	return r, err
+}
+
+func (l *rateLimiter) Reserve(ctx context.Context, key string, n int64) (*RateLimitResult, error) {
+	r, err := l.run(ctx, key, n, math.MaxInt64)
+	if err == nil {
+		l.decision(rateLimitSourceRemote, r.Allowed)
+	}
+	return r, err
+}
+
+func (l *rateLimiter) decision(source string, allowed bool) {
+	result := rateLimitResultAllowed
+	if !allowed {
+		result = rateLimitResultRejected
+	}
+	l.c.handler.rateLimitDecision(l.name, source, result)
+}
+
+func (l *rateLimiter) Wait(ctx context.Context, key string, n int64) error {
+	maxDelay := time.Duration(math.MaxInt64)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxDelay = deadline.Sub(nowFunc())
+	}
+	for {
+		r, err := l.run(ctx, key, n, maxDelay)
+		if err != nil {
+			return err
+		}
+		if r.RetryAfter < 0 {
+			return ErrRateLimitExceedsCapacity
+		}
+		if r.RetryAfter > maxDelay {
+			return ErrRateLimitExceedsDeadline
+		}
+		if r.Allowed && r.RetryAfter == 0 {
+			l.decision(rateLimitSourceRemote, true)
+			return nil
+		}
+		t := time.NewTimer(r.RetryAfter)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		// GCRA 预留成功，等待后即可执行
+		if r.Allowed {
+			l.decision(rateLimitSourceRemote, true)
+			return nil
+		}
+	}
+}
+
+// NewRateLimiter 新建一个限流器，每个 key 在 period 内最多允许 limit 个请求
+// LocalBatch 大于 1 时，每个进程从 Redis 批量租借配额并在本地决策
+func (c *client) NewRateLimiter(name string, limit int64, period time.Duration, opts ...RateLimiterOption) (RateLimiter, error) {
+	l, err := newRateLimiter(c, name, limit, period, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if l.spec.GetLocalBatch() > 1 {
+		return newBatchRateLimiter(l), nil
+	}
+	return l, nil
+}
@@ -0,0 +1,135 @@
+package redisson
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitLease 本地租借的配额，retryAt 之前不再批量租借
+type rateLimitLease struct {
+	tokens   int64
+	expireAt time.Time
+	retryAt  time.Time
+}
+
+// batchRateLimiter 每次从 Redis 租借 LocalBatch 个配额，在本地决策直到配额用完或过期
+// 多个进程的租借配额未用完时，其他进程会被提前拒绝，过期丢弃的配额不会归还，LocalBatch 越大精度越低
+type batchRateLimiter struct {
+	*rateLimiter
+	batch int64
+
+	mu     sync.Mutex
+	leases map[string]*rateLimitLease
+	swept  time.Time
+}
+
+// newBatchRateLimiter LocalBatch 超过单次能够允许的最大数量时永远无法租借，此时限制为该数量
+func newBatchRateLimiter(l *rateLimiter) *batchRateLimiter {
+	batch, capacity := l.spec.GetLocalBatch(), l.limit
+	if l.spec.GetAlgorithm() == RateLimitGCRA {
+		capacity = l.burst()
+	}
+	if batch > capacity {
+		batch = capacity
+	}
+	return &batchRateLimiter{rateLimiter: l, batch: batch, leases: make(map[string]*rateLimitLease), swept: nowFunc()}
+}
+
+// take 从本地租借的配额中扣除 n 个，返回剩余的本地配额
+func (l *batchRateLimiter) take(key string, n int64) (int64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := nowFunc()
+	l.sweep(now)
+	lease, ok := l.leases[key]
+	if !ok || !now.Before(lease.expireAt) || lease.tokens < n {
+		return 0, false
+	}
+	lease.tokens -= n
+	return lease.tokens, true
+}
+
+// store 保存新租借的配额，本地未过期的剩余配额会被保留
+func (l *batchRateLimiter) store(key string, tokens int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := nowFunc()
+	if lease, ok := l.leases[key]; ok && now.Before(lease.expireAt) {
+		tokens += lease.tokens
+	}
+	l.leases[key] = &rateLimitLease{tokens: tokens, expireAt: now.Add(l.spec.GetLocalLeaseTTL())}
+	return tokens
+}
+
+// leasable 批量租借被拒绝后，等待 RetryAfter 之后才再次尝试
+func (l *batchRateLimiter) leasable(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lease, ok := l.leases[key]
+	return !ok || !nowFunc().Before(lease.retryAt)
+}
+
+// backoff 批量租借被拒绝，d 之内只申请请求的数量
+func (l *batchRateLimiter) backoff(key string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lease, ok := l.leases[key]
+	if !ok {
+		lease = &rateLimitLease{}
+		l.leases[key] = lease
+	}
+	lease.retryAt = nowFunc().Add(d)
+}
+
+// sweep 定期清理过期的租借，避免 key 过多时占用内存
+func (l *batchRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.spec.GetLocalLeaseTTL() {
+		return
+	}
+	l.swept = now
+	for key, lease := range l.leases {
+		if !now.Before(lease.expireAt) && !now.Before(lease.retryAt) {
+			delete(l.leases, key)
+		}
+	}
+}
+
+func (l *batchRateLimiter) Allow(ctx context.Context, key string) (*RateLimitResult, error) {
+	return l.AllowN(ctx, key, 1)
+}
+
+func (l *batchRateLimiter) AllowN(ctx context.Context, key string, n int64) (*RateLimitResult, error) {
+	if tokens, ok := l.take(key, n); ok {
+		l.decision(rateLimitSourceLocal, true)
+		return &RateLimitResult{Allowed: true, Limit: l.limit, Remaining: tokens}, nil
+	}
+	if n < l.batch && l.leasable(key) {
+		r, err := l.run(ctx, key, l.batch, 0)
+		if err != nil {
+			return nil, err
+		}
+		if r.Allowed {
+			r.Remaining += l.store(key, l.batch-n)
+			l.decision(rateLimitSourceRemote, true)
+			return r, nil
+		}
+		if r.RetryAfter > 0 {
+			l.backoff(key, r.RetryAfter)
+		}
+	}
+	// 剩余配额不足一个批次时，只申请 n 个
+	return l.rateLimiter.AllowN(ctx, key, n)
+}
+
+func (l *batchRateLimiter) Wait(ctx context.Context, key string, n int64) error {
+	r, err := l.AllowN(ctx, key, n)
+	if err != nil {
+		return err
+	}
+	if r.Allowed {
+		return nil
+	}
+	// 配额不足时不再批量租借，等待 n 个配额
+	return l.rateLimiter.Wait(ctx, key, n)
+}
@@ -0,0 +1,279 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 使用 GCRA 算法依次检查每个 key，全部允许时才扣除配额
+// ARGV 为 n、now 以及每个 key 的 interval、tolerance，返回拒绝的 key 序号（从 1 开始，0 表示全部允许）以及每个 key 的 {是否允许, 剩余配额, 重试等待毫秒数, 配额完全恢复的毫秒数}
+var compositeRateLimitLua = `
+local n, now = tonumber(ARGV[1]), tonumber(ARGV[2])
+local rejected = 0
+local results, tats = {}, {}
+for i, key in ipairs(KEYS) do
+	local interval, tolerance = tonumber(ARGV[1 + 2 * i]), tonumber(ARGV[2 + 2 * i])
+	local tat = tonumber(redis.call('GET', key) or '0')
+	if tat < now then
+		tat = now
+	end
+	local increment = interval * n
+	local new_tat = tat + increment
+	local delay = new_tat - tolerance - now
+	-- 时钟精度为毫秒，小于 1 毫秒的等待视为无需等待，避免浮点误差导致误判
+	if delay < 1 then
+		delay = 0
+	end
+	local allowed, t, retry = 1, new_tat, 0
+	if increment > tolerance + 1e-6 or delay > 0 then
+		allowed, t, retry = 0, tat, math.ceil(delay)
+		if increment > tolerance + 1e-6 then
+			retry = -1
+		end
+		if rejected == 0 then
+			rejected = i
+		end
+	end
+	tats[i] = new_tat
+	table.insert(results, allowed)
+	table.insert(results, math.max(0, math.floor((now - t + tolerance) / interval + 1e-6)))
+	table.insert(results, retry)
+	table.insert(results, math.ceil(t - now))
+end
+if rejected == 0 then
+	for i, key in ipairs(KEYS) do
+		redis.call('SET', key, string.format('%.3f', tats[i]), 'PX', math.ceil(tats[i] - now))
+	end
+end
+table.insert(results, 1, rejected)
+return results
+`
+
+// 归还已扣除的配额，用于按 slot 分组执行时后续分组拒绝的情况
+var refundCompositeRateLimitLua = `
+local n, now = tonumber(ARGV[1]), tonumber(ARGV[2])
+for i, key in ipairs(KEYS) do
+	local tat = tonumber(redis.call('GET', key) or '0')
+	tat = tat - tonumber(ARGV[2 + i]) * n
+	if tat <= now then
+		redis.call('DEL', key)
+	else
+		redis.call('SET', key, string.format('%.3f', tat), 'PX', math.ceil(tat - now))
+	end
+end
+return 1
+`
+
+var (
+	ErrEmptyRateLimitLevels   = errors.New("composite rate limiter levels cannot be empty")
+	ErrRateLimitLevelKeyCount = errors.New("composite rate limiter keys count must equal to levels count")
+)
+
+const (
+	compositeRateLimiterSameSlotKeyFormat = "rl:{%s}:%s:%s"
+	compositeRateLimiterKeyFormat         = "rl:{%s:%s:%s}"
+)
+
+// RateLimitLevel 组合限流器中的一个层级
+type RateLimitLevel struct {
+	// Name 层级名称，如 user、tenant、global，用于标识拒绝请求的层级
+	Name string
+	// Limit 每个 key 在 Period 内允许的请求数量
+	Limit  int64
+	Period time.Duration
+	// Burst 允许的突发数量，小于等于 0 时与 Limit 相同
+	Burst int64
+}
+
+// CompositeRateLimitResult 组合限流结果
+type CompositeRateLimitResult struct {
+	// Allowed 是否所有层级均允许，只有全部允许时才会扣除配额
+	Allowed bool
+	// RejectedLevel 第一个拒绝请求的层级名称
+	RejectedLevel string
+	// Levels 各层级的限流结果，顺序与层级一致，按 slot 分组执行时未检查的层级为 nil
+	Levels []*RateLimitResult
+}
+
+// CompositeRateLimiter 组合限流器，在一次脚本调用中检查并扣除多个层级的配额，如同时限制单个用户、租户以及全局的请求
+// 默认所有 key 使用限流器名称作为 hash tag，保证原子性
+// SameSlot 为 false 时，集群模式下按 slot 分组依次执行，非集群模式依然在一次脚本调用中执行，后续分组拒绝时会尽力归还之前分组已扣除的配额
+type CompositeRateLimiter interface {
+	// Allow 等同于 AllowN(ctx, 1, keys...)
+	Allow(ctx context.Context, keys ...string) (*CompositeRateLimitResult, error)
+	// AllowN 判断是否允许 n 个请求，keys 与层级一一对应，全局层级可以使用空字符串
+	AllowN(ctx context.Context, n int64, keys ...string) (*CompositeRateLimitResult, error)
+}
+
+type compositeRateLimiter struct {
+	c      *client
+	spec   RateLimiterOptionsVisitor
+	name   string
+	levels []RateLimitLevel
+
+	script       Scripter
+	refundScript Scripter
+}
+
+func newCompositeRateLimiter(c *client, name string, levels []RateLimitLevel, opts ...RateLimiterOption) (*compositeRateLimiter, error) {
+	if name == "" {
+		return nil, ErrEmptyRateLimiterName
+	}
+	if len(levels) == 0 {
+		return nil, ErrEmptyRateLimitLevels
+	}
+	for _, level := range levels {
+		if level.Limit <= 0 || level.Period < time.Millisecond {
+			return nil, ErrInvalidRateLimit
+		}
+	}
+	return &compositeRateLimiter{
+		c:            c,
+		spec:         newRateLimiterOptions(opts...),
+		name:         name,
+		levels:       levels,
+		script:       c.CreateScript(compositeRateLimitLua),
+		refundScript: c.CreateScript(refundCompositeRateLimitLua),
+	}, nil
+}
+
+func (l *compositeRateLimiter) key(level RateLimitLevel, key string) string {
+	format := compositeRateLimiterKeyFormat
+	if l.spec.GetSameSlot() {
+		format = compositeRateLimiterSameSlotKeyFormat
+	}
+	k := fmt.Sprintf(format, l.name, level.Name, key)
+	if prefix := l.spec.GetPrefix(); prefix != "" {
+		k = fmt.Sprintf("%s:%s", prefix, k)
+	}
+	return k
+}
+
+// args 返回层级的 interval 以及 tolerance，单位为毫秒
+func (l *compositeRateLimiter) args(level RateLimitLevel) (float64, float64) {
+	interval := float64(level.Period) / float64(time.Millisecond) / float64(level.Limit)
+	burst := level.Burst
+	if burst <= 0 {
+		burst = level.Limit
+	}
+	return interval, interval * float64(burst)
+}
+
+// groups 集群模式下按 slot 分组，保持层级的先后顺序
+func (l *compositeRateLimiter) groups(keys []string) [][]int {
+	if l.spec.GetSameSlot() || !l.c.handler.isCluster() {
+		indexes := make([]int, len(keys))
+		for i := range keys {
+			indexes[i] = i
+		}
+		return [][]int{indexes}
+	}
+	var groups [][]int
+	slots := make(map[uint16]int)
+	for i, key := range keys {
+		s := slot(key)
+		g, ok := slots[s]
+		if !ok {
+			g = len(groups)
+			slots[s] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+func (l *compositeRateLimiter) Allow(ctx context.Context, keys ...string) (*CompositeRateLimitResult, error) {
+	return l.AllowN(ctx, 1, keys...)
+}
+
+func (l *compositeRateLimiter) AllowN(ctx context.Context, n int64, keys ...string) (*CompositeRateLimitResult, error) {
+	if len(keys) != len(l.levels) {
+		return nil, ErrRateLimitLevelKeyCount
+	}
+	fullKeys := make([]string, len(keys))
+	for i, key := range keys {
+		fullKeys[i] = l.key(l.levels[i], key)
+	}
+	now := nowFunc().UnixMilli()
+	r := &CompositeRateLimitResult{Allowed: true, Levels: make([]*RateLimitResult, len(keys))}
+	groups := l.groups(fullKeys)
+	for gi, group := range groups {
+		rejected, err := l.run(ctx, r, fullKeys, group, n, now)
+		if err != nil {
+			l.refund(fullKeys, groups[:gi], n, now)
+			return nil, err
+		}
+		if rejected >= 0 {
+			r.Allowed = false
+			r.RejectedLevel = l.levels[rejected].Name
+			l.refund(fullKeys, groups[:gi], n, now)
+			break
+		}
+	}
+	return r, nil
+}
+
+// run 执行一个分组，返回拒绝请求的层级序号，-1 表示全部允许
+func (l *compositeRateLimiter) run(ctx context.Context, r *CompositeRateLimitResult, keys []string, group []int, n, now int64) (int, error) {
+	groupKeys := make([]string, 0, len(group))
+	args := make([]any, 0, 2+2*len(group))
+	args = append(args, n, now)
+	for _, i := range group {
+		groupKeys = append(groupKeys, keys[i])
+		interval, tolerance := l.args(l.levels[i])
+		args = append(args, interval, tolerance)
+	}
+	res, err := l.script.Run(ctx, groupKeys, args...).Int64Slice()
+	if err != nil {
+		return -1, err
+	}
+	if len(res) != 1+4*len(group) {
+		return -1, fmt.Errorf("unexpected composite rate limit result %v", res)
+	}
+	for j, i := range group {
+		v := res[1+4*j : 5+4*j]
+		lr := &RateLimitResult{
+			Allowed:    v[0] == 1,
+			Limit:      l.levels[i].Limit,
+			Remaining:  v[1],
+			RetryAfter: time.Duration(v[2]) * time.Millisecond,
+			ResetAfter: time.Duration(v[3]) * time.Millisecond,
+		}
+		if v[2] < 0 {
+			lr.RetryAfter = -1
+		}
+		r.Levels[i] = lr
+	}
+	if res[0] == 0 {
+		return -1, nil
+	}
+	return group[res[0]-1], nil
+}
+
+// refund 尽力归还已执行分组扣除的配额，失败时只记录日志
+func (l *compositeRateLimiter) refund(keys []string, groups [][]int, n, now int64) {
+	for _, group := range groups {
+		groupKeys := make([]string, 0, len(group))
+		args := make([]any, 0, 2+len(group))
+		args = append(args, n, now)
+		for _, i := range group {
+			groupKeys = append(groupKeys, keys[i])
+			interval, _ := l.args(l.levels[i])
+			args = append(args, interval)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), l.c.v.GetWriteTimeout())
+		err := l.refundScript.Run(ctx, groupKeys, args...).Err()
+		cancel()
+		if err != nil {
+			warning(l.c.v, "[redis-rate-limiter]: refund failed", Any("name", l.name), errorField(err))
+		}
+	}
+}
+
+// NewCompositeRateLimiter 新建一个组合限流器，levels 为各层级的限流规则，使用 RateLimiterOption 中的 Prefix 以及 SameSlot
+func (c *client) NewCompositeRateLimiter(name string, levels []RateLimitLevel, opts ...RateLimiterOption) (CompositeRateLimiter, error) {
+	return newCompositeRateLimiter(c, name, levels, opts...)
+}
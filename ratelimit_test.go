@@ -0,0 +1,286 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRateLimiter(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var prefix = "mock_rate_limiter"
+
+	Convey("rate limiter options", t, func() {
+		_, err := c.NewRateLimiter("", 1, time.Second)
+		So(err, ShouldEqual, ErrEmptyRateLimiterName)
+		_, err = c.NewRateLimiter("invalid", 0, time.Second)
+		So(err, ShouldEqual, ErrInvalidRateLimit)
+		_, err = c.NewRateLimiter("invalid", 1, time.Microsecond)
+		So(err, ShouldEqual, ErrInvalidRateLimit)
+	})
+
+	for _, algorithm := range []RateLimitAlgorithm{RateLimitFixedWindow, RateLimitSlidingLog, RateLimitSlidingWindow, RateLimitGCRA} {
+		Convey("rate limiter allow", t, func() {
+			l, err := c.NewRateLimiter("allow", 3, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionAlgorithm(algorithm))
+			So(err, ShouldBeNil)
+			// 每次使用新的 key，避免受到之前运行的影响
+			key := newLockToken()
+
+			r, err := l.AllowN(ctx, key, 4)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeFalse)
+			So(r.RetryAfter, ShouldEqual, -1)
+
+			r, err = l.AllowN(ctx, key, 2)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			So(r.Limit, ShouldEqual, 3)
+			So(r.Remaining, ShouldEqual, 1)
+			So(r.ResetAfter, ShouldBeGreaterThan, 0)
+
+			r, err = l.Allow(ctx, key)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			So(r.Remaining, ShouldEqual, 0)
+
+			r, err = l.Allow(ctx, key)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeFalse)
+			So(r.Remaining, ShouldEqual, 0)
+			So(r.RetryAfter, ShouldBeGreaterThan, 0)
+			So(r.RetryAfter, ShouldBeLessThanOrEqualTo, 2*time.Second)
+
+			// 其他 key 不受影响
+			r, err = l.Allow(ctx, newLockToken())
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+		})
+
+		Convey("rate limiter wait", t, func() {
+			l, err := c.NewRateLimiter("wait", 2, 200*time.Millisecond, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionAlgorithm(algorithm))
+			So(err, ShouldBeNil)
+			key := newLockToken()
+
+			So(l.Wait(ctx, key, 3), ShouldEqual, ErrRateLimitExceedsCapacity)
+			So(l.Wait(ctx, key, 2), ShouldBeNil)
+			start := time.Now()
+			So(l.Wait(ctx, key, 1), ShouldBeNil)
+			if algorithm != RateLimitFixedWindow {
+				// 固定窗口在窗口边界处会立即恢复配额
+				So(time.Since(start), ShouldBeGreaterThan, 50*time.Millisecond)
+			}
+
+			So(l.Wait(ctx, key, 2), ShouldBeNil)
+			tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			err = l.Wait(tctx, key, 2)
+			So(errors.Is(err, ErrRateLimitExceedsDeadline) || errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		})
+	}
+
+	Convey("rate limiter gcra reserve", t, func() {
+		l, err := c.NewRateLimiter("reserve", 10, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionBurst(2))
+		So(err, ShouldBeNil)
+		key := newLockToken()
+
+		r, err := l.AllowN(ctx, key, 3)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeFalse)
+		So(r.RetryAfter, ShouldEqual, -1)
+
+		r, err = l.AllowN(ctx, key, 2)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		So(r.RetryAfter, ShouldEqual, 0)
+
+		// 突发用完后，预留未来的配额
+		r, err = l.Reserve(ctx, key, 1)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		So(r.RetryAfter, ShouldBeGreaterThan, 0)
+		So(r.RetryAfter, ShouldBeLessThanOrEqualTo, 100*time.Millisecond)
+
+		r, err = l.Reserve(ctx, key, 1)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		So(r.RetryAfter, ShouldBeGreaterThan, 100*time.Millisecond)
+
+		r, err = l.Allow(ctx, key)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeFalse)
+		So(r.RetryAfter, ShouldBeGreaterThan, 100*time.Millisecond)
+	})
+}
+
+func TestCompositeRateLimiter(t *testing.T) {
+	// 非集群模式下，SameSlot 为 false 时不同 slot 的 key 依然在一次脚本调用中执行
+	c := MustNewClient(NewConf(WithDevelopment(false), WithEnableCache(false), WithForceSingleClient(true)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var prefix = "mock_composite_rate_limiter"
+	var levels = []RateLimitLevel{
+		{Name: "user", Limit: 2, Period: time.Second},
+		{Name: "tenant", Limit: 3, Period: time.Second},
+		{Name: "global", Limit: 100, Period: time.Second},
+	}
+
+	Convey("composite rate limiter options", t, func() {
+		_, err := c.NewCompositeRateLimiter("", levels)
+		So(err, ShouldEqual, ErrEmptyRateLimiterName)
+		_, err = c.NewCompositeRateLimiter("invalid", nil)
+		So(err, ShouldEqual, ErrEmptyRateLimitLevels)
+		_, err = c.NewCompositeRateLimiter("invalid", []RateLimitLevel{{Name: "user"}})
+		So(err, ShouldEqual, ErrInvalidRateLimit)
+	})
+
+	Convey("composite rate limiter groups", t, func() {
+		l := &compositeRateLimiter{c: &client{handler: &baseHandler{}}, spec: newRateLimiterOptions(WithRateLimiterOptionSameSlot(false)), name: "groups", levels: levels}
+		keys := []string{l.key(levels[0], "u1"), l.key(levels[1], "t1"), l.key(levels[2], "")}
+		// 非集群模式不需要按 slot 分组
+		So(l.groups(keys), ShouldResemble, [][]int{{0, 1, 2}})
+		l.c.handler.setIsCluster(true)
+		So(len(l.groups(keys)), ShouldEqual, 3)
+	})
+
+	for _, sameSlot := range []bool{true, false} {
+		Convey("composite rate limiter allow", t, func() {
+			l, err := c.NewCompositeRateLimiter(newLockToken(), levels, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionSameSlot(sameSlot))
+			So(err, ShouldBeNil)
+			_, err = l.Allow(ctx, "u1", "t1")
+			So(err, ShouldEqual, ErrRateLimitLevelKeyCount)
+
+			r, err := l.AllowN(ctx, 2, "u1", "t1", "")
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			So(r.RejectedLevel, ShouldBeEmpty)
+			So(r.Levels[0].Remaining, ShouldEqual, 0)
+			So(r.Levels[1].Remaining, ShouldEqual, 1)
+			So(r.Levels[2].Remaining, ShouldEqual, 98)
+
+			r, err = l.Allow(ctx, "u1", "t1", "")
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeFalse)
+			So(r.RejectedLevel, ShouldEqual, "user")
+			So(r.Levels[0].RetryAfter, ShouldBeGreaterThan, 0)
+
+			// 租户层级剩余 1 个配额，另一个用户耗尽后再次请求被租户层级拒绝
+			r, err = l.Allow(ctx, "u2", "t1", "")
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			r, err = l.Allow(ctx, "u2", "t1", "")
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeFalse)
+			So(r.RejectedLevel, ShouldEqual, "tenant")
+
+			// 被拒绝的请求不扣除任何层级的配额
+			r, err = l.Allow(ctx, "u2", "t2", "")
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			So(r.Levels[0].Remaining, ShouldEqual, 0)
+			So(r.Levels[2].Remaining, ShouldEqual, 96)
+		})
+	}
+}
+
+func TestBatchRateLimiter(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var prefix = "mock_batch_rate_limiter"
+
+	Convey("batch rate limiter", t, func() {
+		l, err := c.NewRateLimiter("batch", 10, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionLocalBatch(4), WithRateLimiterOptionAlgorithm(RateLimitFixedWindow))
+		So(err, ShouldBeNil)
+		_, ok := l.(*batchRateLimiter)
+		So(ok, ShouldBeTrue)
+		key := newLockToken()
+
+		// 第一次租借 4 个配额，之后的 3 次在本地决策
+		r, err := l.Allow(ctx, key)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		for i := 0; i < 3; i++ {
+			r, err = l.Allow(ctx, key)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+			So(r.Remaining, ShouldEqual, 2-i)
+		}
+		So(c.Get(ctx, prefix+":rl:{batch:"+key+"}:"+strconv.FormatInt(nowFunc().UnixMilli()/1000, 10)).Val(), ShouldBeIn, []string{"4", ""})
+
+		// 其他实例同时租借，剩余配额不足一个批次时逐个申请
+		other, err := c.NewRateLimiter("batch", 10, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionLocalBatch(4), WithRateLimiterOptionAlgorithm(RateLimitFixedWindow))
+		So(err, ShouldBeNil)
+		allowed := 0
+		for i := 0; i < 10; i++ {
+			r, err = other.Allow(ctx, key)
+			So(err, ShouldBeNil)
+			if r.Allowed {
+				allowed++
+			}
+		}
+		So(allowed, ShouldBeGreaterThanOrEqualTo, 4)
+		So(allowed, ShouldBeLessThanOrEqualTo, 10)
+	})
+
+	Convey("batch rate limiter backoff", t, func() {
+		l, err := c.NewRateLimiter("backoff", 10, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionLocalBatch(5), WithRateLimiterOptionBurst(2))
+		So(err, ShouldBeNil)
+		key := newLockToken()
+		b := l.(*batchRateLimiter)
+		// 超过 Burst 的批次永远无法租借
+		So(b.batch, ShouldEqual, 2)
+
+		for i := 0; i < 2; i++ {
+			r, err := l.Allow(ctx, key)
+			So(err, ShouldBeNil)
+			So(r.Allowed, ShouldBeTrue)
+		}
+		So(b.leasable(key), ShouldBeTrue)
+		r, err := l.Allow(ctx, key)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeFalse)
+		// 批量租借被拒绝后，RetryAfter 之内直接申请请求的数量
+		So(b.leasable(key), ShouldBeFalse)
+		b.mu.Lock()
+		retryAt := b.leases[key].retryAt
+		b.mu.Unlock()
+		So(retryAt.Sub(nowFunc()), ShouldBeGreaterThanOrEqualTo, r.RetryAfter)
+		time.Sleep(time.Until(retryAt) + 10*time.Millisecond)
+		So(b.leasable(key), ShouldBeTrue)
+	})
+
+	Convey("batch rate limiter lease expire", t, func() {
+		l, err := c.NewRateLimiter("expire", 100, time.Second, WithRateLimiterOptionPrefix(prefix), WithRateLimiterOptionLocalBatch(10), WithRateLimiterOptionLocalLeaseTTL(50*time.Millisecond))
+		So(err, ShouldBeNil)
+		key := newLockToken()
+		b := l.(*batchRateLimiter)
+
+		So(l.Wait(ctx, key, 1), ShouldBeNil)
+		_, ok := b.take(key, 1)
+		So(ok, ShouldBeTrue)
+		time.Sleep(60 * time.Millisecond)
+		_, ok = b.take(key, 1)
+		So(ok, ShouldBeFalse)
+
+		r, err := l.AllowN(ctx, key, 20)
+		So(err, ShouldBeNil)
+		So(r.Allowed, ShouldBeTrue)
+		time.Sleep(60 * time.Millisecond)
+		_, _ = b.take(newLockToken(), 1)
+		b.mu.Lock()
+		So(len(b.leases), ShouldEqual, 0)
+		b.mu.Unlock()
+	})
+}
@@ -11,15 +11,29 @@ type XCmdable interface {
 	RegisterCollector(RegisterCollectorFunc)
 	Cache(ttl time.Duration) CacheCmdable
 	NewLocker(opts ...LockerOption) (Locker, error)
+	NewReadWriteLocker(opts ...LockerOption) (ReadWriteLocker, error)
+	NewReentrantLocker(opts ...LockerOption) (ReentrantLocker, error)
 	NewFunnel(key string, capacity, operations int64, seconds time.Duration) funnel.Funnel
 	NewBloomFilter(name string, expectedNumberOfItems uint, falsePositiveRate float64, opts ...BloomOption) (BloomFilter, error)
+	NewScalableBloomFilter(name string, initialCapacity uint, falsePositiveRate float64, opts ...BloomOption) (BloomFilter, error)
+	NewCountingBloomFilter(name string, expectedNumberOfItems uint, falsePositiveRate float64, opts ...BloomOption) (CountingBloomFilter, error)
+	NewCuckooFilter(name string, capacity uint, opts ...CuckooOption) (CuckooFilter, error)
+	NewCountMinSketch(name string, width, depth uint) (CountMinSketch, error)
+	NewTopK(name string, k uint, opts ...TopKOption) (TopK, error)
 	NewDelayQueue(name string, f func([]byte) error, opts ...DelayOption) (DelayQueue, error)
+	NewStreamDelayQueue(name string, f func(*DelayMessage) error, opts ...StreamDelayOption) (StreamDelayQueue, error)
+	NewScheduler(name string, opts ...SchedulerOption) (Scheduler, error)
+	NewSemaphore(name string, permits int64, opts ...SemaphoreOption) (Semaphore, error)
+	NewLeaderElector(name string, opts ...LeaderOption) (LeaderElector, error)
+	NewRateLimiter(name string, limit int64, period time.Duration, opts ...RateLimiterOption) (RateLimiter, error)
+	NewCompositeRateLimiter(name string, levels []RateLimitLevel, opts ...RateLimiterOption) (CompositeRateLimiter, error)
 	Close() error
 	IsCluster() bool
 	Options() ConfVisitor
 	ForEachNodes(context.Context, func(context.Context, Cmdable) error) error
 	Receive(ctx context.Context, cb func(Message), channels ...string) error
 	PReceive(ctx context.Context, cb func(Message), patterns ...string) error
+	SReceive(ctx context.Context, cb func(Message), channels ...string) error
 	Do(ctx context.Context, completed Completed) RedisResult
 }
 
@@ -18,12 +18,25 @@ type handler interface {
 
 	before(ctx context.Context, command Command) context.Context
 	beforeWithKeys(ctx context.Context, command Command, getKeys func() []string) context.Context
+	beforePipeline(ctx context.Context, command Command, cmds []Completed, sameSlot bool) context.Context
 	after(ctx context.Context, err error)
 	cache(ctx context.Context, hit bool)
 	isCluster() bool
 	delayPollError(name string)
 	delayReclaimError(name string)
 	delayReclaim(name string, count int)
+	delayInflight(name string, delta int)
+	delayProcessed(name string, result string)
+	delayLag(name string, lag time.Duration)
+	delayDuration(name string, d time.Duration)
+	semaphoreAcquire(name string, result string)
+	semaphoreWait(name string, d time.Duration)
+	lockWait(prefix string, d time.Duration)
+	lockLost(prefix string)
+	rateLimitDecision(name string, source string, result string)
+	pubSubState(state string, delta int)
+	pubSubReconnect(result string)
+	pubSubDropped(reason string)
 }
 
 func newSemVersion(version string) (semver.Version, error) {
@@ -90,6 +103,24 @@ func (r *baseHandler) before(ctx context.Context, command Command) context.Conte
 	return r.beforeWithKeys(ctx, command, nil)
 }
 func (r *baseHandler) beforeWithKeys(ctx context.Context, command Command, getKeys func() []string) context.Context {
+	ctx = r.beforeWithSlotCheck(ctx, command, func() { panicIfUseMultipleKeySlots(command, getKeys) })
+	if hooks := r.v.GetHooks(); len(hooks) > 0 {
+		ctx = beforeProcessHooks(ctx, hooks, command, getKeys)
+	}
+	return ctx
+}
+func (r *baseHandler) beforePipeline(ctx context.Context, command Command, cmds []Completed, sameSlot bool) context.Context {
+	ctx = r.beforeWithSlotCheck(ctx, command, func() {
+		if sameSlot {
+			panicIfUseMultipleCompletedSlots(command, cmds)
+		}
+	})
+	if hooks := r.v.GetHooks(); len(hooks) > 0 {
+		ctx = beforePipelineHooks(ctx, hooks, command, cmds)
+	}
+	return ctx
+}
+func (r *baseHandler) beforeWithSlotCheck(ctx context.Context, command Command, checkSlots func()) context.Context {
 	if r.v.GetDevelopment() {
 		if skipCheck := ctx.Value(skipCheckContextKey); skipCheck == nil {
 			// 需要检验命令是否在黑名单
@@ -102,7 +133,7 @@ func (r *baseHandler) beforeWithKeys(ctx context.Context, command Command, getKe
 			}
 			if r.cluster {
 				// 需要检验所有的key是否均在同一槽位
-				panicIfUseMultipleKeySlots(command, getKeys)
+				checkSlots()
 			}
 			// 该命令是否有警告日志输出
 			if r.version != nil {
@@ -120,17 +151,14 @@ func (r *baseHandler) beforeWithKeys(ctx context.Context, command Command, getKe
 						needWarning = true
 					}
 					if needWarning {
-						instead := command.Instead()
-						etc := command.ETC()
-						if len(instead) > 0 && len(etc) > 0 {
-							warning(fmt.Sprintf("[%s]: %s \n\t\t use '%s' instead. \n\t\t %s, etc.", command.String(), command.Warning(), instead, etc))
-						} else if len(instead) > 0 {
-							warning(fmt.Sprintf("[%s]: %s \n\t\t use '%s' instead.", command.String(), command.Warning(), instead))
-						} else if len(etc) > 0 {
-							warning(fmt.Sprintf("[%s]: %s \n\t\t %s, etc.", command.String(), command.Warning(), etc))
-						} else {
-							warning(fmt.Sprintf("[%s]: %s", command.String(), command.Warning()))
+						fields := []Field{commandField(command)}
+						if instead := command.Instead(); len(instead) > 0 {
+							fields = append(fields, Any("instead", instead))
+						}
+						if etc := command.ETC(); len(etc) > 0 {
+							fields = append(fields, Any("etc", etc))
 						}
+						warning(r.v, fmt.Sprintf("[%s]: %s", command.String(), command.Warning()), fields...)
 					}
 				}
 			}
@@ -150,6 +178,9 @@ func (r *baseHandler) isImplicitError(err error) bool {
 	return r.silentErrCallback(err)
 }
 func (r *baseHandler) after(ctx context.Context, err error) {
+	if hooks := r.v.GetHooks(); len(hooks) > 0 {
+		afterHooks(ctx, hooks, err)
+	}
 	if r.v.GetEnableMonitor() {
 		if err != nil && !r.isImplicitError(err) {
 			errMetric.WithLabelValues(ctx.Value(commandContextKey).(string), ctx.Value(subCommandContextKey).(string)).Inc()
@@ -184,3 +215,63 @@ func (r *baseHandler) delayReclaim(name string, count int) {
 		delayReclaimCountMetric.WithLabelValues(name).Add(float64(count))
 	}
 }
+func (r *baseHandler) delayInflight(name string, delta int) {
+	if r.v.GetEnableMonitor() {
+		delayInflightMetric.WithLabelValues(name).Add(float64(delta))
+	}
+}
+func (r *baseHandler) delayProcessed(name string, result string) {
+	if r.v.GetEnableMonitor() {
+		delayProcessedMetric.WithLabelValues(name, result).Inc()
+	}
+}
+func (r *baseHandler) delayLag(name string, lag time.Duration) {
+	if r.v.GetEnableMonitor() {
+		delayLagMetric.WithLabelValues(name).Observe(lag.Seconds())
+	}
+}
+func (r *baseHandler) delayDuration(name string, d time.Duration) {
+	if r.v.GetEnableMonitor() {
+		delayDurationMetric.WithLabelValues(name).Observe(d.Seconds())
+	}
+}
+func (r *baseHandler) semaphoreAcquire(name string, result string) {
+	if r.v.GetEnableMonitor() {
+		semaphoreAcquireMetric.WithLabelValues(name, result).Inc()
+	}
+}
+func (r *baseHandler) semaphoreWait(name string, d time.Duration) {
+	if r.v.GetEnableMonitor() {
+		semaphoreWaitMetric.WithLabelValues(name).Observe(d.Seconds())
+	}
+}
+func (r *baseHandler) lockWait(prefix string, d time.Duration) {
+	if r.v.GetEnableMonitor() {
+		lockWaitMetric.WithLabelValues(prefix).Observe(d.Seconds())
+	}
+}
+func (r *baseHandler) lockLost(prefix string) {
+	if r.v.GetEnableMonitor() {
+		lockLostMetric.WithLabelValues(prefix).Inc()
+	}
+}
+func (r *baseHandler) rateLimitDecision(name string, source string, result string) {
+	if r.v.GetEnableMonitor() {
+		rateLimitDecisionMetric.WithLabelValues(name, source, result).Inc()
+	}
+}
+func (r *baseHandler) pubSubState(state string, delta int) {
+	if r.v.GetEnableMonitor() {
+		pubSubStateMetric.WithLabelValues(state).Add(float64(delta))
+	}
+}
+func (r *baseHandler) pubSubReconnect(result string) {
+	if r.v.GetEnableMonitor() {
+		pubSubReconnectMetric.WithLabelValues(result).Inc()
+	}
+}
+func (r *baseHandler) pubSubDropped(reason string) {
+	if r.v.GetEnableMonitor() {
+		pubSubDroppedMetric.WithLabelValues(reason).Inc()
+	}
+}
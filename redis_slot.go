@@ -11,6 +11,27 @@ func panicIfUseMultipleKeySlots(command Command, f func() []string) {
 	}
 }
 
+// 与 rueidis 中 cmds.InitSlot、cmds.NoSlot 一致，表示命令没有 key
+const (
+	initSlot = uint16(1 << 14)
+	noSlot   = uint16(1 << 15)
+)
+
+func panicIfUseMultipleCompletedSlots(command Command, cmds []Completed) {
+	var pre = -1
+	for _, cmd := range cmds {
+		s := cmd.Slot()
+		if s == initSlot || s == noSlot {
+			continue
+		}
+		s &= 16383
+		if pre >= 0 && uint16(pre) != s {
+			panic(fmt.Errorf("[%s]: multiple keys command with different key slots are not allowed", command.String()))
+		}
+		pre = int(s)
+	}
+}
+
 func checkSlots(command Command, keys ...string) error {
 	if len(keys) <= 1 {
 		return nil
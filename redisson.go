@@ -33,6 +33,9 @@ type client struct {
 	maxp        int
 	delayQueues sync.Map
 
+	streamDelayQueues sync.Map
+	schedulers        sync.Map
+
 	once sync.Once
 }
 
@@ -0,0 +1,381 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var registerScheduleJobLua = `
+local sched_set, spec_hash = KEYS[1], KEYS[2]
+local job, spec, score = ARGV[1], ARGV[2], ARGV[3]
+if redis.call('HGET', spec_hash, job) ~= spec or not redis.call('ZSCORE', sched_set, job) then
+	redis.call('HSET', spec_hash, job, spec)
+	redis.call('ZADD', sched_set, score, job)
+end
+return {true}
+`
+
+var scheduleJobScoresLua = `
+local sched_set = KEYS[1]
+local res = {}
+for i, job in ipairs(ARGV) do
+	res[i] = redis.call('ZSCORE', sched_set, job) or ''
+end
+return res
+`
+
+var claimScheduleJobLua = `
+local sched_set = KEYS[1]
+local job, expected, next = ARGV[1], ARGV[2], ARGV[3]
+local score = redis.call('ZSCORE', sched_set, job)
+if not score or tonumber(score) ~= tonumber(expected) then
+	return 0
+end
+redis.call('ZADD', sched_set, next, job)
+return 1
+`
+
+var removeScheduleJobLua = `
+local sched_set, spec_hash = KEYS[1], KEYS[2]
+local job = ARGV[1]
+redis.call('ZREM', sched_set, job)
+redis.call('HDEL', spec_hash, job)
+return {true}
+`
+
+var (
+	ErrEmptySchedulerName      = errors.New("scheduler name cannot be empty")
+	ErrEmptyScheduleJobName    = errors.New("schedule job name cannot be empty")
+	ErrEmptyScheduleCallback   = errors.New("schedule job callback cannot be empty")
+	ErrScheduleJobExists       = errors.New("schedule job already exists")
+	ErrScheduleJobNotFound     = errors.New("schedule job not found")
+	ErrSchedulerHasClosed      = errors.New("scheduler has closed")
+	ErrInvalidScheduleInterval = errors.New("schedule interval must be positive")
+)
+
+const (
+	schedulerLogPrefix     = "[redis-scheduler]:"
+	schedulerKeyFormat     = "sched:{%s}"
+	schedulerSpecKeyFormat = "sched:spec:{%s}"
+	schedulerJobFieldName  = "job"
+	schedulerNeverRunScore = "+inf"
+)
+
+// MisfirePolicy 错过执行时间后的处理策略，如所有实例都停止运行期间错过的执行
+type MisfirePolicy int
+
+const (
+	// MisfireFireOnce 错过的执行只补偿一次，之后从当前时间开始计算下一次执行时间
+	MisfireFireOnce MisfirePolicy = iota
+	// MisfireSkip 错过超过 MisfireThreshold 的执行直接跳过
+	MisfireSkip
+	// MisfireCatchUp 逐个补偿所有错过的执行
+	MisfireCatchUp
+)
+
+// ScheduleFunc 定时任务，scheduledAt 为本次执行计划的时间
+type ScheduleFunc func(ctx context.Context, scheduledAt time.Time) error
+
+// Scheduler 分布式定时任务调度器，下一次执行时间保存在 Redis 中
+// 多个实例注册相同的任务时，每次执行只会由其中一个实例触发
+type Scheduler interface {
+	// Cron 注册 cron 表达式任务，表达式格式参考 ParseCron
+	// 任务的表达式发生变化时，会重新计算下一次执行时间
+	Cron(job, spec string, f ScheduleFunc) error
+	// Every 注册固定间隔任务
+	Every(job string, interval time.Duration, f ScheduleFunc) error
+	// Remove 移除任务，所有实例都不会再执行该任务
+	Remove(ctx context.Context, job string) error
+	// Next 返回任务的下一次执行时间，不再执行时返回零值
+	Next(ctx context.Context, job string) (time.Time, error)
+	// Close 关闭调度器，等待正在执行的任务结束
+	Close() error
+}
+
+type scheduleJob struct {
+	name     string
+	spec     string
+	schedule Schedule
+	f        ScheduleFunc
+}
+
+type scheduler struct {
+	c       *client
+	spec    SchedulerOptionsVisitor
+	name    string
+	key     string
+	specKey string
+
+	mx   sync.RWMutex
+	jobs map[string]*scheduleJob
+
+	running atomic.Bool
+	exitC   chan struct{}
+	wakeC   chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	jobWg   sync.WaitGroup
+
+	registerScript Scripter
+	scoresScript   Scripter
+	claimScript    Scripter
+	removeScript   Scripter
+}
+
+func newScheduler(c *client, name string, opts ...SchedulerOption) (*scheduler, error) {
+	if name == "" {
+		return nil, ErrEmptySchedulerName
+	}
+	spec := newSchedulerOptions(opts...)
+	s := &scheduler{
+		c:              c,
+		spec:           spec,
+		name:           name,
+		key:            fmt.Sprintf(schedulerKeyFormat, name),
+		specKey:        fmt.Sprintf(schedulerSpecKeyFormat, name),
+		jobs:           make(map[string]*scheduleJob),
+		exitC:          make(chan struct{}),
+		wakeC:          make(chan struct{}, 1),
+		registerScript: c.CreateScript(registerScheduleJobLua),
+		scoresScript:   c.CreateScript(scheduleJobScoresLua),
+		claimScript:    c.CreateScript(claimScheduleJobLua),
+		removeScript:   c.CreateScript(removeScheduleJobLua),
+	}
+	if prefix := spec.GetPrefix(); prefix != "" {
+		s.key = fmt.Sprintf("%s:%s", prefix, s.key)
+		s.specKey = fmt.Sprintf("%s:%s", prefix, s.specKey)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.running.Store(true)
+	s.wg.Add(1)
+	go s.loop()
+	return s, nil
+}
+
+func (s *scheduler) location() *time.Location {
+	if loc := s.spec.GetLocation(); loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+func (s *scheduler) Cron(job, spec string, f ScheduleFunc) error {
+	sched, err := ParseCron(spec, s.location())
+	if err != nil {
+		return err
+	}
+	return s.register(job, spec, sched, f)
+}
+
+func (s *scheduler) Every(job string, interval time.Duration, f ScheduleFunc) error {
+	if interval <= 0 {
+		return ErrInvalidScheduleInterval
+	}
+	return s.register(job, "@every "+interval.String(), everySchedule{interval: interval}, f)
+}
+
+func (s *scheduler) register(job, spec string, sched Schedule, f ScheduleFunc) error {
+	if !s.running.Load() {
+		return ErrSchedulerHasClosed
+	}
+	if job == "" {
+		return ErrEmptyScheduleJobName
+	}
+	if f == nil {
+		return ErrEmptyScheduleCallback
+	}
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.jobs[job]; ok {
+		return ErrScheduleJobExists
+	}
+	next := scheduleScore(sched.Next(nowFunc()))
+	if err := s.registerScript.Run(context.Background(), []string{s.key, s.specKey}, job, spec, next).Err(); err != nil {
+		return err
+	}
+	s.jobs[job] = &scheduleJob{name: job, spec: spec, schedule: sched, f: f}
+	select {
+	case s.wakeC <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *scheduler) Remove(ctx context.Context, job string) error {
+	s.mx.Lock()
+	delete(s.jobs, job)
+	s.mx.Unlock()
+	return s.removeScript.Run(ctx, []string{s.key, s.specKey}, job).Err()
+}
+
+func (s *scheduler) Next(ctx context.Context, job string) (time.Time, error) {
+	score, err := s.c.ZScore(ctx, s.key, job).Result()
+	if err != nil {
+		if IsNil(err) {
+			err = ErrScheduleJobNotFound
+		}
+		return time.Time{}, err
+	}
+	if math.IsInf(score, 1) {
+		// 不再执行
+		return time.Time{}, nil
+	}
+	return time.UnixMilli(int64(score)), nil
+}
+
+func (s *scheduler) Close() error {
+	if !s.running.CompareAndSwap(true, false) {
+		return ErrSchedulerHasClosed
+	}
+	close(s.exitC)
+	s.wg.Wait()
+	s.cancel()
+	s.jobWg.Wait()
+	s.c.schedulers.Delete(s.name)
+	return nil
+}
+
+// scheduleScore 返回执行时间对应的分数，零值表示不再执行
+func scheduleScore(t time.Time) string {
+	if t.IsZero() {
+		return schedulerNeverRunScore
+	}
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+func (s *scheduler) loop() {
+	t := time.NewTimer(0)
+	defer func() {
+		_ = t.Stop()
+		s.wg.Done()
+	}()
+	for {
+		select {
+		case <-t.C:
+		case <-s.wakeC:
+			if !t.Stop() {
+				select {
+				case <-t.C:
+				default:
+				}
+			}
+		case <-s.exitC:
+			return
+		}
+		d, err := s.poll()
+		if err != nil {
+			e(s.c.v, schedulerLogPrefix+" poll error", Any("scheduler", s.name), errorField(err))
+		}
+		_ = t.Reset(d)
+	}
+}
+
+// poll 触发到期的任务，返回距离下一次到期的时间，最长为 PollInterval
+func (s *scheduler) poll() (time.Duration, error) {
+	interval := s.spec.GetPollInterval()
+	s.mx.RLock()
+	jobs := make([]*scheduleJob, 0, len(s.jobs))
+	args := make([]any, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+		args = append(args, j.name)
+	}
+	s.mx.RUnlock()
+	if len(jobs) == 0 {
+		return interval, nil
+	}
+	scores, err := s.scoresScript.Run(context.Background(), []string{s.key}, args...).StringSlice()
+	if err != nil {
+		return interval, err
+	}
+	now := nowFunc()
+	wait := interval
+	for i, j := range jobs {
+		if i >= len(scores) || scores[i] == "" {
+			// 任务被其他实例移除
+			continue
+		}
+		score, err0 := strconv.ParseFloat(scores[i], 64)
+		if err0 != nil || math.IsInf(score, 1) {
+			continue
+		}
+		at := time.UnixMilli(int64(score))
+		if at.After(now) {
+			if d := at.Sub(now); d < wait {
+				wait = d
+			}
+			continue
+		}
+		claimed, next, err0 := s.fire(j, scores[i], at, now)
+		if err0 != nil {
+			err = err0
+			continue
+		}
+		// 补偿模式下，下一次执行时间可能依然已经到期
+		if claimed && !next.IsZero() && !next.After(now) {
+			wait = 0
+		}
+	}
+	return wait, err
+}
+
+// fire 通过比较并更新下一次执行时间，保证每次执行只由一个实例触发
+func (s *scheduler) fire(j *scheduleJob, expected string, at, now time.Time) (bool, time.Time, error) {
+	run := true
+	next := j.schedule.Next(at)
+	switch s.spec.GetMisfirePolicy() {
+	case MisfireCatchUp:
+	case MisfireSkip:
+		run = now.Sub(at) <= s.spec.GetMisfireThreshold()
+		fallthrough
+	default:
+		if !next.IsZero() && !next.After(now) {
+			next = j.schedule.Next(now)
+		}
+	}
+	n, err := s.claimScript.Run(context.Background(), []string{s.key}, j.name, expected, scheduleScore(next)).Int64()
+	if err != nil || n == 0 {
+		return false, next, err
+	}
+	if !run {
+		warning(s.c.v, schedulerLogPrefix+" skip misfired job", Any("scheduler", s.name), Any(schedulerJobFieldName, j.name), Any("scheduled_at", at))
+		return true, next, nil
+	}
+	s.jobWg.Add(1)
+	go func() {
+		defer s.jobWg.Done()
+		s.run(j, at)
+	}()
+	return true, next, nil
+}
+
+func (s *scheduler) run(j *scheduleJob, at time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			e(s.c.v, schedulerLogPrefix+" job panic", Any("scheduler", s.name), Any(schedulerJobFieldName, j.name), Any("panic", r))
+		}
+	}()
+	if err := j.f(s.ctx, at); err != nil {
+		e(s.c.v, schedulerLogPrefix+" job error", Any("scheduler", s.name), Any(schedulerJobFieldName, j.name), errorField(err))
+	}
+}
+
+// NewScheduler 新建一个分布式定时任务调度器
+func (c *client) NewScheduler(name string, opts ...SchedulerOption) (Scheduler, error) {
+	if val, ok := c.schedulers.Load(name); ok {
+		return val.(*scheduler), nil
+	}
+	s, err := newScheduler(c, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.schedulers.Store(s.name, s)
+	return s, nil
+}
@@ -0,0 +1,126 @@
+package redisson
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseCron(t *testing.T) {
+	var base = time.Date(2024, 1, 31, 10, 30, 15, 0, time.UTC)
+	var next = func(spec string) time.Time {
+		s, err := ParseCron(spec, time.UTC)
+		So(err, ShouldBeNil)
+		return s.Next(base)
+	}
+
+	Convey("cron next", t, func() {
+		So(next("* * * * *"), ShouldEqual, time.Date(2024, 1, 31, 10, 31, 0, 0, time.UTC))
+		So(next("*/15 * * * *"), ShouldEqual, time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC))
+		So(next("0 9-17 * * mon-fri"), ShouldEqual, time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC))
+		So(next("0 0 29 2 *"), ShouldEqual, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+		So(next("0 0 * * 0"), ShouldEqual, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC))
+		So(next("0 0 * * 7"), ShouldEqual, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC))
+		So(next("30 * * * * *"), ShouldEqual, time.Date(2024, 1, 31, 10, 30, 30, 0, time.UTC))
+		So(next("@daily"), ShouldEqual, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		So(next("@every 90s"), ShouldEqual, base.Add(90*time.Second))
+		// 日与周均有限制时，满足其一即可
+		So(next("0 0 1 * fri"), ShouldEqual, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	Convey("invalid cron", t, func() {
+		for _, spec := range []string{"", "* * *", "60 * * * *", "* * * 13 *", "*/0 * * * *", "@every -1s", "a * * * *"} {
+			_, err := ParseCron(spec, nil)
+			So(err, ShouldWrap, ErrInvalidCronSpec)
+		}
+	})
+}
+
+func TestScheduler(t *testing.T) {
+	var clients []Cmdable
+	for i := 0; i < 2; i++ {
+		c := MustNewClient(NewConf(WithDevelopment(false)))
+		t.Cleanup(func() {
+			_ = c.Close()
+		})
+		clients = append(clients, c)
+	}
+	var ctx = context.Background()
+	var prefix = "mock_scheduler"
+
+	Convey("fire once across instances", t, func() {
+		var mx sync.Mutex
+		var fired = make(map[time.Time]int)
+		var ss []Scheduler
+		for _, c := range clients {
+			s, err := c.NewScheduler("fire", WithSchedulerOptionPrefix(prefix), WithSchedulerOptionPollInterval(50*time.Millisecond))
+			So(err, ShouldBeNil)
+			So(s.Every("job", 200*time.Millisecond, func(ctx context.Context, at time.Time) error {
+				mx.Lock()
+				defer mx.Unlock()
+				fired[at]++
+				return nil
+			}), ShouldBeNil)
+			ss = append(ss, s)
+		}
+		So(ss[0].Every("job", time.Second, func(context.Context, time.Time) error { return nil }), ShouldEqual, ErrScheduleJobExists)
+
+		time.Sleep(1100 * time.Millisecond)
+		for _, s := range ss {
+			So(s.Close(), ShouldBeNil)
+		}
+		mx.Lock()
+		defer mx.Unlock()
+		So(len(fired), ShouldBeGreaterThanOrEqualTo, 4)
+		for _, n := range fired {
+			So(n, ShouldEqual, 1)
+		}
+	})
+
+	Convey("remove and next", t, func() {
+		s, err := clients[0].NewScheduler("remove", WithSchedulerOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+		So(s.Cron("job", "bad", func(context.Context, time.Time) error { return nil }), ShouldWrap, ErrInvalidCronSpec)
+		So(s.Cron("job", "@hourly", func(context.Context, time.Time) error { return nil }), ShouldBeNil)
+
+		next, err := s.Next(ctx, "job")
+		So(err, ShouldBeNil)
+		So(next.After(nowFunc()), ShouldBeTrue)
+		So(next.Sub(nowFunc()), ShouldBeLessThanOrEqualTo, time.Hour)
+
+		So(s.Remove(ctx, "job"), ShouldBeNil)
+		_, err = s.Next(ctx, "job")
+		So(err, ShouldEqual, ErrScheduleJobNotFound)
+		So(s.Close(), ShouldBeNil)
+		So(s.Close(), ShouldEqual, ErrSchedulerHasClosed)
+	})
+
+	Convey("misfire catch up", t, func() {
+		s, err := clients[0].NewScheduler("misfire", WithSchedulerOptionPrefix(prefix), WithSchedulerOptionMisfirePolicy(MisfireCatchUp))
+		So(err, ShouldBeNil)
+		// 模拟所有实例停止期间错过了 3 次执行
+		var start = nowFunc().Add(-250 * time.Millisecond).Truncate(time.Millisecond)
+		So(clients[0].Del(ctx, prefix+":sched:{misfire}", prefix+":sched:spec:{misfire}").Err(), ShouldBeNil)
+		So(clients[0].HSet(ctx, prefix+":sched:spec:{misfire}", "job", "@every 100ms").Err(), ShouldBeNil)
+		So(clients[0].ZAdd(ctx, prefix+":sched:{misfire}", Z{Score: float64(start.UnixMilli()), Member: "job"}).Err(), ShouldBeNil)
+
+		var mx sync.Mutex
+		var fired []time.Time
+		So(s.Every("job", 100*time.Millisecond, func(ctx context.Context, at time.Time) error {
+			mx.Lock()
+			defer mx.Unlock()
+			fired = append(fired, at)
+			return nil
+		}), ShouldBeNil)
+		time.Sleep(100 * time.Millisecond)
+		So(s.Close(), ShouldBeNil)
+
+		mx.Lock()
+		defer mx.Unlock()
+		So(len(fired), ShouldBeGreaterThanOrEqualTo, 3)
+		So(fired[0], ShouldEqual, start)
+	})
+}
@@ -0,0 +1,221 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 每个许可为有序集合中的一个成员 "持有者ID:序号"，分数为许可的过期时间
+// 过期时间使用 Redis 服务器的时间，避免时钟超前的实例回收其他实例仍然有效的许可
+var semaphoreNowLua = `
+if redis.replicate_commands then
+	redis.replicate_commands()
+end
+local function server_now()
+	local t = redis.call('TIME')
+	return tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+end
+`
+
+var acquireSemaphoreLua = semaphoreNowLua + `
+local sem = KEYS[1]
+local id, n, permits, ttl = ARGV[1], tonumber(ARGV[2]), tonumber(ARGV[3]), tonumber(ARGV[4])
+local now = server_now()
+local expire = now + ttl
+redis.call('ZREMRANGEBYSCORE', sem, '-inf', now)
+if redis.call('ZCARD', sem) + n > permits then
+	return 0
+end
+for i = 1, n do
+	redis.call('ZADD', sem, expire, id .. ':' .. i)
+end
+if redis.call('PTTL', sem) < ttl then
+	redis.call('PEXPIRE', sem, ttl)
+end
+return 1
+`
+
+var releaseSemaphoreLua = `
+local sem = KEYS[1]
+local id = ARGV[1]
+local i = 1
+while redis.call('ZREM', sem, id .. ':' .. i) == 1 do
+	i = i + 1
+end
+return i - 1
+`
+
+var refreshSemaphoreLua = semaphoreNowLua + `
+local sem = KEYS[1]
+local id, ttl = ARGV[1], tonumber(ARGV[2])
+local now = server_now()
+local expire = now + ttl
+local i = 1
+while true do
+	local member = id .. ':' .. i
+	local score = redis.call('ZSCORE', sem, member)
+	if not score or tonumber(score) <= now then
+		break
+	end
+	redis.call('ZADD', sem, expire, member)
+	i = i + 1
+end
+if i > 1 and redis.call('PTTL', sem) < ttl then
+	redis.call('PEXPIRE', sem, ttl)
+end
+return i - 1
+`
+
+var availableSemaphoreLua = semaphoreNowLua + `
+local sem = KEYS[1]
+local permits, now = tonumber(ARGV[1]), server_now()
+redis.call('ZREMRANGEBYSCORE', sem, '-inf', now)
+return permits - redis.call('ZCARD', sem)
+`
+
+var (
+	ErrEmptySemaphoreName     = errors.New("semaphore name cannot be empty")
+	ErrInvalidSemaphorePermit = errors.New("semaphore permits must be positive")
+	ErrSemaphoreNoPermits     = errors.New("semaphore has no enough permits")
+	ErrSemaphorePermitExpired = errors.New("semaphore permit has expired")
+)
+
+const (
+	semaphoreKeyFormat = "sem:{%s}"
+
+	semaphoreResultAcquired = "acquired"
+	semaphoreResultRejected = "rejected"
+	semaphoreResultCanceled = "canceled"
+)
+
+// Semaphore 分布式信号量，限制多个实例的总并发数
+// 许可有租期，持有者崩溃后许可会在 LeaseTTL 后被自动回收，长时间持有需要定期 Refresh
+type Semaphore interface {
+	// Acquire 等待获取 n 个许可，返回持有者 ID，用于 Release 以及 Refresh
+	Acquire(ctx context.Context, n int64) (string, error)
+	// TryAcquire 尝试获取 n 个许可，许可不足时返回 ErrSemaphoreNoPermits
+	TryAcquire(ctx context.Context, n int64) (string, error)
+	// Release 释放持有者的全部许可
+	Release(ctx context.Context, id string) error
+	// Refresh 延长持有者许可的租期，许可已过期时返回 ErrSemaphorePermitExpired
+	Refresh(ctx context.Context, id string) error
+	// Available 剩余可用的许可数量
+	Available(ctx context.Context) (int64, error)
+}
+
+type semaphore struct {
+	c       *client
+	spec    SemaphoreOptionsVisitor
+	name    string
+	key     string
+	permits int64
+
+	acquireScript   Scripter
+	releaseScript   Scripter
+	refreshScript   Scripter
+	availableScript Scripter
+}
+
+func newSemaphore(c *client, name string, permits int64, opts ...SemaphoreOption) (*semaphore, error) {
+	if name == "" {
+		return nil, ErrEmptySemaphoreName
+	}
+	if permits <= 0 {
+		return nil, ErrInvalidSemaphorePermit
+	}
+	spec := newSemaphoreOptions(opts...)
+	s := &semaphore{
+		c:               c,
+		spec:            spec,
+		name:            name,
+		key:             fmt.Sprintf(semaphoreKeyFormat, name),
+		permits:         permits,
+		acquireScript:   c.CreateScript(acquireSemaphoreLua),
+		releaseScript:   c.CreateScript(releaseSemaphoreLua),
+		refreshScript:   c.CreateScript(refreshSemaphoreLua),
+		availableScript: c.CreateScript(availableSemaphoreLua),
+	}
+	if prefix := spec.GetPrefix(); prefix != "" {
+		s.key = fmt.Sprintf("%s:%s", prefix, s.key)
+	}
+	return s, nil
+}
+
+func (s *semaphore) try(ctx context.Context, id string, n int64) (bool, error) {
+	res, err := s.acquireScript.Run(ctx, []string{s.key}, id, n, s.permits, s.spec.GetLeaseTTL().Milliseconds()).Int64()
+	return res == 1, err
+}
+
+func (s *semaphore) Acquire(ctx context.Context, n int64) (string, error) {
+	if n <= 0 || n > s.permits {
+		return "", ErrInvalidSemaphorePermit
+	}
+	id := newLockToken()
+	start := nowFunc()
+	for {
+		ok, err := s.try(ctx, id, n)
+		if err != nil {
+			if ctx.Err() != nil {
+				s.c.handler.semaphoreAcquire(s.name, semaphoreResultCanceled)
+				s.c.handler.semaphoreWait(s.name, nowFunc().Sub(start))
+				return "", ctx.Err()
+			}
+			return "", err
+		}
+		if ok {
+			s.c.handler.semaphoreAcquire(s.name, semaphoreResultAcquired)
+			s.c.handler.semaphoreWait(s.name, nowFunc().Sub(start))
+			return id, nil
+		}
+		select {
+		case <-ctx.Done():
+			s.c.handler.semaphoreAcquire(s.name, semaphoreResultCanceled)
+			s.c.handler.semaphoreWait(s.name, nowFunc().Sub(start))
+			return "", ctx.Err()
+		case <-time.After(s.spec.GetRetryInterval()):
+		}
+	}
+}
+
+func (s *semaphore) TryAcquire(ctx context.Context, n int64) (string, error) {
+	if n <= 0 || n > s.permits {
+		return "", ErrInvalidSemaphorePermit
+	}
+	id := newLockToken()
+	ok, err := s.try(ctx, id, n)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		s.c.handler.semaphoreAcquire(s.name, semaphoreResultRejected)
+		return "", ErrSemaphoreNoPermits
+	}
+	s.c.handler.semaphoreAcquire(s.name, semaphoreResultAcquired)
+	return id, nil
+}
+
+func (s *semaphore) Release(ctx context.Context, id string) error {
+	return s.releaseScript.Run(ctx, []string{s.key}, id).Err()
+}
+
+func (s *semaphore) Refresh(ctx context.Context, id string) error {
+	n, err := s.refreshScript.Run(ctx, []string{s.key}, id, s.spec.GetLeaseTTL().Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSemaphorePermitExpired
+	}
+	return nil
+}
+
+func (s *semaphore) Available(ctx context.Context) (int64, error) {
+	return s.availableScript.Run(ctx, []string{s.key}, s.permits).Int64()
+}
+
+// NewSemaphore 新建一个分布式信号量，permits 为总许可数量，相同名称的信号量应使用相同的 permits
+func (c *client) NewSemaphore(name string, permits int64, opts ...SemaphoreOption) (Semaphore, error) {
+	return newSemaphore(c, name, permits, opts...)
+}
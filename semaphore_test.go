@@ -0,0 +1,126 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSemaphore(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+	var prefix = "mock_semaphore"
+
+	Convey("semaphore permits", t, func() {
+		_, err := c.NewSemaphore("", 1)
+		So(err, ShouldEqual, ErrEmptySemaphoreName)
+		_, err = c.NewSemaphore("permits", 0)
+		So(err, ShouldEqual, ErrInvalidSemaphorePermit)
+
+		s, err := c.NewSemaphore("permits", 3, WithSemaphoreOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, prefix+":sem:{permits}").Err(), ShouldBeNil)
+		_, err = s.TryAcquire(ctx, 4)
+		So(err, ShouldEqual, ErrInvalidSemaphorePermit)
+
+		id1, err := s.TryAcquire(ctx, 2)
+		So(err, ShouldBeNil)
+		n, err := s.Available(ctx)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 1)
+		_, err = s.TryAcquire(ctx, 2)
+		So(err, ShouldEqual, ErrSemaphoreNoPermits)
+		id2, err := s.TryAcquire(ctx, 1)
+		So(err, ShouldBeNil)
+
+		tctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err = s.Acquire(tctx, 1)
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		So(s.Release(ctx, id1), ShouldBeNil)
+		So(s.Release(ctx, id2), ShouldBeNil)
+		n, err = s.Available(ctx)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 3)
+	})
+
+	Convey("semaphore lease", t, func() {
+		s, err := c.NewSemaphore("lease", 1, WithSemaphoreOptionPrefix(prefix), WithSemaphoreOptionLeaseTTL(300*time.Millisecond))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, prefix+":sem:{lease}").Err(), ShouldBeNil)
+
+		id, err := s.TryAcquire(ctx, 1)
+		So(err, ShouldBeNil)
+		time.Sleep(200 * time.Millisecond)
+		So(s.Refresh(ctx, id), ShouldBeNil)
+		time.Sleep(200 * time.Millisecond)
+		_, err = s.TryAcquire(ctx, 1)
+		So(err, ShouldEqual, ErrSemaphoreNoPermits)
+
+		// 持有者崩溃，许可过期后被回收
+		var start = time.Now()
+		id2, err := s.Acquire(ctx, 1)
+		So(err, ShouldBeNil)
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+		So(s.Refresh(ctx, id), ShouldEqual, ErrSemaphorePermitExpired)
+		So(s.Release(ctx, id2), ShouldBeNil)
+	})
+
+	Convey("semaphore clock skew", t, func() {
+		s, err := c.NewSemaphore("skew", 1, WithSemaphoreOptionPrefix(prefix))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, prefix+":sem:{skew}").Err(), ShouldBeNil)
+		id, err := s.TryAcquire(ctx, 1)
+		So(err, ShouldBeNil)
+
+		// 本地时钟超前时，不能回收其他实例仍然有效的许可
+		nowFunc = func() time.Time { return time.Now().Add(time.Hour) }
+		defer func() { nowFunc = time.Now }()
+		_, err = s.TryAcquire(ctx, 1)
+		So(err, ShouldEqual, ErrSemaphoreNoPermits)
+		n, err := s.Available(ctx)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 0)
+		So(s.Release(ctx, id), ShouldBeNil)
+	})
+
+	Convey("semaphore concurrency", t, func() {
+		s, err := c.NewSemaphore("concurrency", 2, WithSemaphoreOptionPrefix(prefix), WithSemaphoreOptionRetryInterval(5*time.Millisecond))
+		So(err, ShouldBeNil)
+		So(c.Del(ctx, prefix+":sem:{concurrency}").Err(), ShouldBeNil)
+
+		var current, peak atomic.Int64
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				id, err0 := s.Acquire(ctx, 1)
+				if err0 != nil {
+					return
+				}
+				v := current.Add(1)
+				for {
+					p := peak.Load()
+					if v <= p || peak.CompareAndSwap(p, v) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				current.Add(-1)
+				_ = s.Release(ctx, id)
+			}()
+		}
+		wg.Wait()
+		So(peak.Load(), ShouldEqual, 2)
+	})
+}
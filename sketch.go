@@ -0,0 +1,278 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/murmur3"
+)
+
+// Count-Min Sketch 为 depth 行 width 列的 32 位计数器，计数器达到上限后不再增加
+// 每个元素的参数为 depth 个列序号，由调用方计算
+var sketchFuncLua = `
+local sketch_max = 4294967295
+local function sketch_offset(width, row, col)
+	return ((row - 1) * width + col) * 32
+end
+local function sketch_incr(key, width, cols, increment)
+	local min
+	for row, col in ipairs(cols) do
+		local offset = sketch_offset(width, row, col)
+		local v = redis.call('BITFIELD', key, 'GET', 'u32', offset)[1] + increment
+		if v > sketch_max then
+			v = sketch_max
+		elseif v < 0 then
+			v = 0
+		end
+		redis.call('BITFIELD', key, 'SET', 'u32', offset, v)
+		if not min or v < min then
+			min = v
+		end
+	end
+	return min
+end
+local function sketch_query(key, width, cols)
+	local min
+	for row, col in ipairs(cols) do
+		local v = redis.call('BITFIELD', key, 'GET', 'u32', sketch_offset(width, row, col))[1]
+		if not min or v < min then
+			min = v
+		end
+	end
+	return min
+end
+local function sketch_cols(from, depth)
+	local cols = {}
+	for i = from, from + depth - 1 do
+		table.insert(cols, tonumber(ARGV[i]))
+	end
+	return cols
+end
+`
+
+// ARGV 为 depth、width 以及每个元素的 {增量, 列序号...}，返回每个元素增加后的计数
+var countMinSketchIncrByLua = sketchFuncLua + `
+local depth, width = tonumber(ARGV[1]), tonumber(ARGV[2])
+local result = {}
+for i = 3, #ARGV, depth + 1 do
+	table.insert(result, sketch_incr(KEYS[1], width, sketch_cols(i + 1, depth), tonumber(ARGV[i])))
+end
+return result
+`
+
+var countMinSketchQueryLua = sketchFuncLua + `
+local depth, width = tonumber(ARGV[1]), tonumber(ARGV[2])
+local result = {}
+for i = 3, #ARGV, depth do
+	table.insert(result, sketch_query(KEYS[1], width, sketch_cols(i, depth)))
+end
+return result
+`
+
+// 将其他 sketch 的内容累加到目标 sketch，ARGV 为字节数以及其他 sketch 的内容
+var countMinSketchMergeLua = `
+local size = tonumber(ARGV[1])
+local dest = redis.call('GET', KEYS[1]) or ''
+local function counter(s, i)
+	local p = i * 4 + 1
+	if p + 3 > #s then
+		return 0
+	end
+	local b1, b2, b3, b4 = string.byte(s, p, p + 3)
+	return ((b1 * 256 + b2) * 256 + b3) * 256 + b4
+end
+-- 分段拼接，避免单个 table 过大
+local out, chunk = {}, {}
+for i = 0, size / 4 - 1 do
+	local v = counter(dest, i)
+	for a = 2, #ARGV do
+		v = v + counter(ARGV[a], i)
+	end
+	if v > 4294967295 then
+		v = 4294967295
+	end
+	table.insert(chunk, string.char(math.floor(v / 16777216) % 256, math.floor(v / 65536) % 256, math.floor(v / 256) % 256, v % 256))
+	if #chunk == 256 then
+		table.insert(out, table.concat(chunk))
+		chunk = {}
+	end
+end
+table.insert(out, table.concat(chunk))
+redis.call('SET', KEYS[1], table.concat(out))
+return 1
+`
+
+var (
+	ErrEmptySketchName          = errors.New("sketch name cannot be empty")
+	ErrInvalidSketchSize        = errors.New("sketch width and depth must be greater than 0")
+	ErrSketchSizeTooLarge       = errors.New("sketch size is too large")
+	ErrSketchIncrementsMismatch = errors.New("sketch items count must equal to increments count")
+	ErrSketchMergeMismatch      = errors.New("sketch to merge has different width or depth")
+)
+
+const (
+	countMinSketchKeyFormat = "cms:{%s}"
+	sketchCounterBits       = 32
+)
+
+// CountMinSketch based on Redis Bitmaps with 32-bit counters.
+// CountMinSketch uses 128-bit murmur3 hash function. The estimated count is never less than the actual count.
+type CountMinSketch interface {
+	// IncrBy increases the count of an item by increment, and returns the estimated count after increasing.
+	IncrBy(ctx context.Context, item string, increment int64) (int64, error)
+
+	// IncrByMulti increases the counts of one or more items, and returns the estimated counts after increasing.
+	IncrByMulti(ctx context.Context, items []string, increments []int64) ([]int64, error)
+
+	// Query returns the estimated counts of one or more items.
+	Query(ctx context.Context, items ...string) ([]int64, error)
+
+	// Merge adds the counts of other sketches with the same width and depth to this sketch.
+	// NOTE: Sources are read before merging, increments to them during merging may be lost.
+	Merge(ctx context.Context, sources ...string) error
+
+	// Reset resets the sketch.
+	Reset(ctx context.Context) error
+
+	// Delete deletes the sketch.
+	Delete(ctx context.Context) error
+}
+
+// sketch depth 行 width 列的计数器
+type sketch struct {
+	width, depth uint64
+}
+
+func newSketch(name string, width, depth uint) (sketch, error) {
+	if len(name) == 0 {
+		return sketch{}, ErrEmptySketchName
+	}
+	if width == 0 || depth == 0 {
+		return sketch{}, ErrInvalidSketchSize
+	}
+	if uint64(width)*uint64(depth)*sketchCounterBits > 1<<32 {
+		return sketch{}, ErrSketchSizeTooLarge
+	}
+	return sketch{width: uint64(width), depth: uint64(depth)}, nil
+}
+
+// cols 返回元素在每一行的列序号
+func (s sketch) cols(item string) []any {
+	h1, h2 := murmur3.Sum128([]byte(item))
+	cols := make([]any, 0, s.depth)
+	for i := uint64(0); i < s.depth; i++ {
+		cols = append(cols, (h1+i*h2)%s.width)
+	}
+	return cols
+}
+
+type countMinSketch struct {
+	sketch
+	c    *client
+	name string
+	key  string
+
+	incrByScript Scripter
+	queryScript  Scripter
+	mergeScript  Scripter
+}
+
+func newCountMinSketch(c *client, name string, width, depth uint) (CountMinSketch, error) {
+	s, err := newSketch(name, width, depth)
+	if err != nil {
+		return nil, err
+	}
+	return &countMinSketch{
+		sketch:       s,
+		c:            c,
+		name:         name,
+		key:          fmt.Sprintf(countMinSketchKeyFormat, name),
+		incrByScript: c.CreateScript(countMinSketchIncrByLua),
+		queryScript:  c.CreateScript(countMinSketchQueryLua),
+		mergeScript:  c.CreateScript(countMinSketchMergeLua),
+	}, nil
+}
+
+func (s *countMinSketch) IncrBy(ctx context.Context, item string, increment int64) (int64, error) {
+	counts, err := s.IncrByMulti(ctx, []string{item}, []int64{increment})
+	if err != nil {
+		return 0, err
+	}
+	return counts[0], nil
+}
+
+func (s *countMinSketch) IncrByMulti(ctx context.Context, items []string, increments []int64) ([]int64, error) {
+	if len(items) != len(increments) {
+		return nil, ErrSketchIncrementsMismatch
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, 2+len(items)*int(s.depth+1))
+	args = append(args, s.depth, s.width)
+	for i, item := range items {
+		args = append(args, increments[i])
+		args = append(args, s.cols(item)...)
+	}
+	return s.incrByScript.Run(ctx, []string{s.key}, args...).Int64Slice()
+}
+
+func (s *countMinSketch) Query(ctx context.Context, items ...string) ([]int64, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, 2+len(items)*int(s.depth))
+	args = append(args, s.depth, s.width)
+	for _, item := range items {
+		args = append(args, s.cols(item)...)
+	}
+	return s.queryScript.Run(ctx, []string{s.key}, args...).Int64Slice()
+}
+
+func (s *countMinSketch) Merge(ctx context.Context, sources ...string) error {
+	keys := make([]string, 0, len(sources))
+	for _, source := range sources {
+		keys = append(keys, fmt.Sprintf(countMinSketchKeyFormat, source))
+	}
+	return s.mergeKeys(ctx, keys)
+}
+
+// mergeKeys 将 keys 对应的 sketch 累加到当前 sketch
+func (s *countMinSketch) mergeKeys(ctx context.Context, keys []string) error {
+	size := s.width * s.depth * sketchCounterBits / 8
+	args := make([]any, 0, 1+len(keys))
+	args = append(args, size)
+	for _, key := range keys {
+		// 其他 sketch 可能位于不同的 slot，先读取内容再合并
+		v, err := s.c.Get(ctx, key).Result()
+		if IsNil(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if uint64(len(v)) > size {
+			return ErrSketchMergeMismatch
+		}
+		args = append(args, v)
+	}
+	if len(args) == 1 {
+		return nil
+	}
+	return s.mergeScript.Run(ctx, []string{s.key}, args...).Err()
+}
+
+func (s *countMinSketch) Reset(ctx context.Context) error {
+	return s.c.Set(ctx, s.key, "", 0).Err()
+}
+
+func (s *countMinSketch) Delete(ctx context.Context) error {
+	return s.c.Del(ctx, s.key).Err()
+}
+
+// NewCountMinSketch 新建一个 Count-Min Sketch，相同名称的 sketch 应使用相同的 width 以及 depth
+// 误差不超过总计数的 e / width 的概率为 1 - e^-depth
+func (c *client) NewCountMinSketch(name string, width, depth uint) (CountMinSketch, error) {
+	return newCountMinSketch(c, name, width, depth)
+}
@@ -0,0 +1,161 @@
+package redisson
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCountMinSketch(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("count-min sketch", t, func() {
+		_, err := c.NewCountMinSketch("", 100, 5)
+		So(err, ShouldEqual, ErrEmptySketchName)
+		_, err = c.NewCountMinSketch("mock_cms", 0, 5)
+		So(err, ShouldEqual, ErrInvalidSketchSize)
+
+		s, err := c.NewCountMinSketch("mock_cms", 1000, 5)
+		So(err, ShouldBeNil)
+		So(s.Delete(ctx), ShouldBeNil)
+
+		count, err := s.IncrBy(ctx, "a", 3)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 3)
+		counts, err := s.IncrByMulti(ctx, []string{"a", "b"}, []int64{2, 7})
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{5, 7})
+		_, err = s.IncrByMulti(ctx, []string{"a"}, nil)
+		So(err, ShouldEqual, ErrSketchIncrementsMismatch)
+
+		counts, err = s.Query(ctx, "a", "b", "c")
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{5, 7, 0})
+
+		other, err := c.NewCountMinSketch("mock_cms_other", 1000, 5)
+		So(err, ShouldBeNil)
+		So(other.Delete(ctx), ShouldBeNil)
+		_, err = other.IncrByMulti(ctx, []string{"a", "c"}, []int64{10, 4})
+		So(err, ShouldBeNil)
+		So(s.Merge(ctx, "mock_cms_other", "mock_cms_missing"), ShouldBeNil)
+		counts, err = s.Query(ctx, "a", "b", "c")
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{15, 7, 4})
+
+		// 合并后仍然可以继续计数
+		count, err = s.IncrBy(ctx, "c", 1)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 5)
+
+		small, err := c.NewCountMinSketch("mock_cms_small", 10, 5)
+		So(err, ShouldBeNil)
+		So(small.Merge(ctx, "mock_cms"), ShouldEqual, ErrSketchMergeMismatch)
+
+		So(s.Reset(ctx), ShouldBeNil)
+		counts, err = s.Query(ctx, "a")
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{0})
+		So(s.Delete(ctx), ShouldBeNil)
+		So(other.Delete(ctx), ShouldBeNil)
+	})
+}
+
+func TestTopK(t *testing.T) {
+	c := MustNewClient(NewConf(WithDevelopment(false)))
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	var ctx = context.Background()
+
+	Convey("top-k", t, func() {
+		_, err := c.NewTopK("mock_topk", 0)
+		So(err, ShouldEqual, ErrInvalidTopK)
+
+		tk, err := c.NewTopK("mock_topk", 3)
+		So(err, ShouldBeNil)
+		So(tk.Delete(ctx), ShouldBeNil)
+
+		expelled, err := tk.Add(ctx, "a", "b", "c", "a")
+		So(err, ShouldBeNil)
+		So(expelled, ShouldResemble, []string{"", "", "", ""})
+		_, err = tk.IncrByMulti(ctx, []string{"b", "c"}, []int64{5, 2})
+		So(err, ShouldBeNil)
+
+		// d 的计数小于最小的 a，不会进入 top-k
+		item, err := tk.IncrBy(ctx, "d", 1)
+		So(err, ShouldBeNil)
+		So(item, ShouldBeEmpty)
+		item, err = tk.IncrBy(ctx, "d", 9)
+		So(err, ShouldBeNil)
+		So(item, ShouldEqual, "a")
+
+		list, err := tk.List(ctx)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, []TopKItem{{Item: "d", Count: 10}, {Item: "b", Count: 6}, {Item: "c", Count: 3}})
+		exists, err := tk.Query(ctx, "a", "b", "d")
+		So(err, ShouldBeNil)
+		So(exists, ShouldResemble, []bool{false, true, true})
+		counts, err := tk.Count(ctx, "a", "d")
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{2, 10})
+
+		var items []string
+		for i := 0; i < 100; i++ {
+			items = append(items, fmt.Sprintf("item-%d", i))
+		}
+		_, err = tk.Add(ctx, items...)
+		So(err, ShouldBeNil)
+		list, err = tk.List(ctx)
+		So(err, ShouldBeNil)
+		So(len(list), ShouldEqual, 3)
+		So(list[0].Item, ShouldEqual, "d")
+		So(tk.Delete(ctx), ShouldBeNil)
+	})
+
+	Convey("top-k merge", t, func() {
+		tk, err := c.NewTopK("mock_topk_dest", 2)
+		So(err, ShouldBeNil)
+		So(tk.Delete(ctx), ShouldBeNil)
+		_, err = tk.IncrBy(ctx, "a", 5)
+		So(err, ShouldBeNil)
+
+		src1, err := c.NewTopK("mock_topk_src1", 2)
+		So(err, ShouldBeNil)
+		So(src1.Delete(ctx), ShouldBeNil)
+		_, err = src1.IncrByMulti(ctx, []string{"b", "c"}, []int64{4, 1})
+		So(err, ShouldBeNil)
+		src2, err := c.NewTopK("mock_topk_src2", 2)
+		So(err, ShouldBeNil)
+		So(src2.Delete(ctx), ShouldBeNil)
+		_, err = src2.IncrByMulti(ctx, []string{"b", "d"}, []int64{3, 2})
+		So(err, ShouldBeNil)
+
+		// b 在两个 top-k 中的计数累加后超过 a
+		So(tk.Merge(ctx, "mock_topk_src1", "mock_topk_src2", "mock_topk_missing"), ShouldBeNil)
+		list, err := tk.List(ctx)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, []TopKItem{{Item: "b", Count: 7}, {Item: "a", Count: 5}})
+		counts, err := tk.Count(ctx, "c", "d")
+		So(err, ShouldBeNil)
+		So(counts, ShouldResemble, []int64{1, 2})
+
+		// 合并后仍然可以继续计数
+		item, err := tk.IncrBy(ctx, "d", 4)
+		So(err, ShouldBeNil)
+		So(item, ShouldEqual, "a")
+
+		small, err := c.NewTopK("mock_topk_small", 2, WithTopKOptionWidth(10))
+		So(err, ShouldBeNil)
+		So(small.Merge(ctx, "mock_topk_dest"), ShouldEqual, ErrSketchMergeMismatch)
+
+		So(tk.Delete(ctx), ShouldBeNil)
+		So(src1.Delete(ctx), ShouldBeNil)
+		So(src2.Delete(ctx), ShouldBeNil)
+	})
+}
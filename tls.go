@@ -0,0 +1,137 @@
+package redisson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTLSCertKeyPairRequired = errors.New("tls cert file and key file must be set together")
+	ErrTLSInvalidCAFile       = errors.New("tls ca file contains no valid certificate")
+)
+
+// tlsLoader 从磁盘加载 CA 证书以及客户端证书
+// 每次建立连接时会检查文件的修改时间，证书轮换后，新建立的连接会使用新的证书
+type tlsLoader struct {
+	caFile, certFile, keyFile string
+
+	mx          sync.Mutex
+	caModTime   time.Time
+	certModTime time.Time
+	keyModTime  time.Time
+	pool        *x509.CertPool
+	cert        *tls.Certificate
+}
+
+func modTime(file string) (time.Time, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// rootCAs 返回 CA 证书池，未设置 CA 文件时返回 nil，即使用系统证书池
+func (l *tlsLoader) rootCAs() (*x509.CertPool, error) {
+	if l.caFile == "" {
+		return nil, nil
+	}
+	mt, err := modTime(l.caFile)
+	if err != nil {
+		return nil, err
+	}
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.pool != nil && mt.Equal(l.caModTime) {
+		return l.pool, nil
+	}
+	bs, err := os.ReadFile(l.caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bs) {
+		return nil, ErrTLSInvalidCAFile
+	}
+	l.pool, l.caModTime = pool, mt
+	return l.pool, nil
+}
+
+// certificate 返回客户端证书，用于 mTLS
+func (l *tlsLoader) certificate() (*tls.Certificate, error) {
+	certModTime, err := modTime(l.certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyModTime, err := modTime(l.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if l.cert != nil && certModTime.Equal(l.certModTime) && keyModTime.Equal(l.keyModTime) {
+		return l.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	l.cert, l.certModTime, l.keyModTime = &cert, certModTime, keyModTime
+	return l.cert, nil
+}
+
+// dial 每次建立连接时使用最新的 CA 证书，未设置 TLSServerName 时按照连接的地址校验服务器证书
+// 集群节点的地址通常为 IP，此时校验证书中的 IP SAN
+func (l *tlsLoader) dial(dst string, dialer *net.Dialer, cfg *tls.Config) (net.Conn, error) {
+	cfg = cfg.Clone()
+	if !cfg.InsecureSkipVerify {
+		pool, err := l.rootCAs()
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(dst)
+		if err != nil {
+			host = dst
+		}
+		cfg.ServerName = host
+	}
+	return tls.DialWithDialer(dialer, "tcp", dst, cfg)
+}
+
+// newTLSConfig 根据配置生成 tls.Config 以及证书的加载器，未开启 TLS 时返回 nil
+func newTLSConfig(v ConfVisitor) (*tls.Config, *tlsLoader, error) {
+	if !v.GetEnableTLS() {
+		return nil, nil, nil
+	}
+	if (v.GetTLSCertFile() == "") != (v.GetTLSKeyFile() == "") {
+		return nil, nil, ErrTLSCertKeyPairRequired
+	}
+	l := &tlsLoader{caFile: v.GetTLSCAFile(), certFile: v.GetTLSCertFile(), keyFile: v.GetTLSKeyFile()}
+	// 提前加载一次，尽早暴露配置错误
+	if _, err := l.rootCAs(); err != nil {
+		return nil, nil, fmt.Errorf("load tls ca file %q failed, %w", l.caFile, err)
+	}
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         v.GetTLSServerName(),
+		InsecureSkipVerify: v.GetTLSInsecureSkipVerify(),
+	}
+	if l.certFile != "" {
+		if _, err := l.certificate(); err != nil {
+			return nil, nil, fmt.Errorf("load tls key pair %q, %q failed, %w", l.certFile, l.keyFile, err)
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return l.certificate()
+		}
+	}
+	return cfg, l, nil
+}
@@ -0,0 +1,191 @@
+package redisson
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert 未指定 hosts 时证书的 SAN 为 localhost 以及 127.0.0.1
+func newTestCert(t *testing.T, parent *testCert, isCA bool, cn string, hosts ...string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed, %v", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if len(hosts) > 0 {
+		tmpl.DNSNames, tmpl.IPAddresses = nil, nil
+		for _, host := range hosts {
+			if ip := net.ParseIP(host); ip != nil {
+				tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+			} else {
+				tmpl.DNSNames = append(tmpl.DNSNames, host)
+			}
+		}
+	}
+	if isCA {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate failed, %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	if err != nil {
+		t.Fatalf("load key pair failed, %v", err)
+	}
+	return cert
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		t.Fatalf("write file failed, %v", err)
+	}
+	return p
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, nil, true, "ca")
+	server := newTestCert(t, ca, false, "server")
+	cli := newTestCert(t, ca, false, "client")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	s := miniredis.NewMiniRedis()
+	err := s.StartTLS(&tls.Config{
+		Certificates: []tls.Certificate{server.tlsCertificate(t)},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatalf("start tls miniredis failed, %v", err)
+	}
+	t.Cleanup(s.Close)
+
+	caFile := writeFile(t, dir, "ca.pem", ca.certPEM)
+	certFile := writeFile(t, dir, "client.pem", cli.certPEM)
+	keyFile := writeFile(t, dir, "client.key", cli.keyPEM)
+
+	var pingAddr = func(addr string, opts ...ConfOption) error {
+		opt, err0 := confVisitor2ClientOption(NewConf(append([]ConfOption{WithAddrs(addr), WithEnableCache(false), WithAlwaysRESP2(true), WithEnableTLS(true)}, opts...)...))
+		if err0 != nil {
+			return err0
+		}
+		c, err0 := rueidis.NewClient(opt)
+		if err0 != nil {
+			return err0
+		}
+		defer c.Close()
+		return c.Do(context.Background(), c.B().Ping().Build()).Error()
+	}
+	var ping = func(opts ...ConfOption) error { return pingAddr(s.Addr(), opts...) }
+
+	Convey("mTLS", t, func() {
+		So(ping(WithTLSCAFile(caFile), WithTLSCertFile(certFile), WithTLSKeyFile(keyFile), WithTLSServerName("localhost")), ShouldBeNil)
+	})
+
+	Convey("verify ip address", t, func() {
+		So(ping(WithTLSCAFile(caFile), WithTLSCertFile(certFile), WithTLSKeyFile(keyFile)), ShouldBeNil)
+	})
+
+	Convey("mismatched server name", t, func() {
+		evil := newTestCert(t, ca, false, "evil", "10.9.9.9", "evil.example")
+		s0 := miniredis.NewMiniRedis()
+		So(s0.StartTLS(&tls.Config{Certificates: []tls.Certificate{evil.tlsCertificate(t)}}), ShouldBeNil)
+		defer s0.Close()
+		So(pingAddr(s0.Addr(), WithTLSCAFile(caFile)), ShouldNotBeNil)
+		So(pingAddr(s0.Addr(), WithTLSCAFile(caFile), WithTLSServerName("localhost")), ShouldNotBeNil)
+		So(pingAddr(s0.Addr(), WithTLSCAFile(caFile), WithTLSServerName("evil.example")), ShouldBeNil)
+	})
+
+	Convey("mTLS without client certificate", t, func() {
+		So(ping(WithTLSCAFile(caFile)), ShouldNotBeNil)
+	})
+
+	Convey("unknown ca", t, func() {
+		other := newTestCert(t, nil, true, "other")
+		So(ping(WithTLSCAFile(writeFile(t, dir, "other.pem", other.certPEM)), WithTLSCertFile(certFile), WithTLSKeyFile(keyFile)), ShouldNotBeNil)
+		So(ping(WithTLSCAFile(writeFile(t, dir, "other.pem", other.certPEM)), WithTLSCertFile(certFile), WithTLSKeyFile(keyFile), WithTLSInsecureSkipVerify(true)), ShouldBeNil)
+	})
+
+	Convey("cert key pair required", t, func() {
+		_, _, err0 := newTLSConfig(NewConf(WithEnableTLS(true), WithTLSCertFile(certFile)))
+		So(err0, ShouldEqual, ErrTLSCertKeyPairRequired)
+	})
+
+	Convey("reload certificate", t, func() {
+		l := &tlsLoader{caFile: caFile, certFile: certFile, keyFile: keyFile}
+		c0, err0 := l.certificate()
+		So(err0, ShouldBeNil)
+		p0, err0 := l.rootCAs()
+		So(err0, ShouldBeNil)
+
+		rotated := newTestCert(t, ca, false, "rotated")
+		writeFile(t, dir, "client.pem", rotated.certPEM)
+		writeFile(t, dir, "client.key", rotated.keyPEM)
+		writeFile(t, dir, "ca.pem", append(ca.certPEM, rotated.certPEM...))
+		future := time.Now().Add(time.Minute)
+		for _, f := range []string{caFile, certFile, keyFile} {
+			So(os.Chtimes(f, future, future), ShouldBeNil)
+		}
+
+		c1, err0 := l.certificate()
+		So(err0, ShouldBeNil)
+		So(c1, ShouldNotEqual, c0)
+		So(c1.Certificate[0], ShouldResemble, rotated.tlsCertificate(t).Certificate[0])
+		p1, err0 := l.rootCAs()
+		So(err0, ShouldBeNil)
+		So(p1, ShouldNotEqual, p0)
+	})
+}
@@ -0,0 +1,264 @@
+package redisson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 使用 Count-Min Sketch 计数，有序集合只保留计数最大的 k 个元素
+// ARGV 为 depth、width、k 以及每个元素的 {元素, 增量, 列序号...}，返回每个元素被挤出 top-k 的元素，没有时为空字符串
+var topKIncrByLua = sketchFuncLua + `
+local sketch, heap = KEYS[1], KEYS[2]
+local depth, width, k = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+local result = {}
+for i = 4, #ARGV, depth + 2 do
+	local item = ARGV[i]
+	local count = sketch_incr(sketch, width, sketch_cols(i + 2, depth), tonumber(ARGV[i + 1]))
+	local expelled = ''
+	if redis.call('ZSCORE', heap, item) or redis.call('ZCARD', heap) < k then
+		redis.call('ZADD', heap, count, item)
+	else
+		local min = redis.call('ZRANGE', heap, 0, 0, 'WITHSCORES')
+		if count > tonumber(min[2]) then
+			redis.call('ZREM', heap, min[1])
+			redis.call('ZADD', heap, count, item)
+			expelled = min[1]
+		end
+	end
+	table.insert(result, expelled)
+end
+return result
+`
+
+var topKQueryLua = `
+local result = {}
+for i = 1, #ARGV do
+	if redis.call('ZSCORE', KEYS[1], ARGV[i]) then
+		table.insert(result, 1)
+	else
+		table.insert(result, 0)
+	end
+end
+return result
+`
+
+// 合并 sketch 后使用合并后的计数重建有序集合，只保留计数最大的 k 个元素
+// ARGV 为 depth、width、k 以及每个候选元素的 {元素, 列序号...}
+var topKRebuildLua = sketchFuncLua + `
+local sketch, heap = KEYS[1], KEYS[2]
+local depth, width, k = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+for i = 4, #ARGV, depth + 1 do
+	redis.call('ZADD', heap, sketch_query(sketch, width, sketch_cols(i + 1, depth)), ARGV[i])
+end
+if redis.call('ZCARD', heap) > k then
+	redis.call('ZREMRANGEBYRANK', heap, 0, -k - 1)
+end
+return 1
+`
+
+var ErrInvalidTopK = errors.New("top-k k must be greater than 0")
+
+const (
+	topKSketchKeyFormat = "topk:{%s}"
+	topKHeapKeyFormat   = "topk:{%s}:heap"
+	topKMinWidth        = 256
+)
+
+// TopKItem top-k 中的元素以及估算的计数
+type TopKItem struct {
+	Item  string
+	Count int64
+}
+
+// TopK tracks the k items with the highest counts, based on Count-Min Sketch and a sorted set with at most k members.
+type TopK interface {
+	// Add increases the counts of one or more items by 1.
+	// Returns the items expelled from the top-k for each item, an empty string means nothing was expelled.
+	Add(ctx context.Context, items ...string) ([]string, error)
+
+	// IncrBy increases the count of an item by increment, and returns the item expelled from the top-k if any.
+	IncrBy(ctx context.Context, item string, increment int64) (string, error)
+
+	// IncrByMulti increases the counts of one or more items.
+	// Returns the items expelled from the top-k for each item, an empty string means nothing was expelled.
+	IncrByMulti(ctx context.Context, items []string, increments []int64) ([]string, error)
+
+	// Query checks if one or more items are in the top-k.
+	Query(ctx context.Context, items ...string) ([]bool, error)
+
+	// Count returns the estimated counts of one or more items.
+	Count(ctx context.Context, items ...string) ([]int64, error)
+
+	// List returns the top-k items ordered by count from high to low.
+	List(ctx context.Context) ([]TopKItem, error)
+
+	// Merge merges other top-k into this one, the other top-k must have the same width and depth.
+	// The counts are summed up, and the items with the highest merged counts are kept.
+	Merge(ctx context.Context, sources ...string) error
+
+	// Delete deletes the top-k.
+	Delete(ctx context.Context) error
+}
+
+type topK struct {
+	sketch
+	c      *client
+	k      uint
+	key    string
+	heap   string
+	counts *countMinSketch
+
+	incrByScript  Scripter
+	queryScript   Scripter
+	rebuildScript Scripter
+}
+
+func newTopK(c *client, name string, k uint, opts ...TopKOption) (TopK, error) {
+	if k == 0 {
+		return nil, ErrInvalidTopK
+	}
+	spec := newTopKOptions(opts...)
+	width := spec.GetWidth()
+	if width == 0 {
+		width = k * 8
+		if width < topKMinWidth {
+			width = topKMinWidth
+		}
+	}
+	s, err := newSketch(name, width, spec.GetDepth())
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf(topKSketchKeyFormat, name)
+	return &topK{
+		sketch: s,
+		c:      c,
+		k:      k,
+		key:    key,
+		heap:   fmt.Sprintf(topKHeapKeyFormat, name),
+		// 复用 Count-Min Sketch 的查询
+		counts: &countMinSketch{
+			sketch:      s,
+			c:           c,
+			name:        name,
+			key:         key,
+			queryScript: c.CreateScript(countMinSketchQueryLua),
+			mergeScript: c.CreateScript(countMinSketchMergeLua),
+		},
+		incrByScript:  c.CreateScript(topKIncrByLua),
+		queryScript:   c.CreateScript(topKQueryLua),
+		rebuildScript: c.CreateScript(topKRebuildLua),
+	}, nil
+}
+
+func (t *topK) Add(ctx context.Context, items ...string) ([]string, error) {
+	increments := make([]int64, len(items))
+	for i := range increments {
+		increments[i] = 1
+	}
+	return t.IncrByMulti(ctx, items, increments)
+}
+
+func (t *topK) IncrBy(ctx context.Context, item string, increment int64) (string, error) {
+	expelled, err := t.IncrByMulti(ctx, []string{item}, []int64{increment})
+	if err != nil {
+		return "", err
+	}
+	return expelled[0], nil
+}
+
+func (t *topK) IncrByMulti(ctx context.Context, items []string, increments []int64) ([]string, error) {
+	if len(items) != len(increments) {
+		return nil, ErrSketchIncrementsMismatch
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, 3+len(items)*int(t.depth+2))
+	args = append(args, t.depth, t.width, t.k)
+	for i, item := range items {
+		args = append(args, item, increments[i])
+		args = append(args, t.cols(item)...)
+	}
+	return t.incrByScript.Run(ctx, []string{t.key, t.heap}, args...).StringSlice()
+}
+
+func (t *topK) Query(ctx context.Context, items ...string) ([]bool, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, len(items))
+	for _, item := range items {
+		args = append(args, item)
+	}
+	return int64SliceToBools(t.queryScript.Run(ctx, []string{t.heap}, args...).Int64Slice())
+}
+
+func (t *topK) Count(ctx context.Context, items ...string) ([]int64, error) {
+	return t.counts.Query(ctx, items...)
+}
+
+func (t *topK) List(ctx context.Context) ([]TopKItem, error) {
+	zs, err := t.c.ZRevRangeWithScores(ctx, t.heap, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]TopKItem, 0, len(zs))
+	for _, z := range zs {
+		items = append(items, TopKItem{Item: fmt.Sprint(z.Member), Count: int64(z.Score)})
+	}
+	return items, nil
+}
+
+func (t *topK) Merge(ctx context.Context, sources ...string) error {
+	if len(sources) == 0 {
+		return nil
+	}
+	// 候选元素为当前以及其他 top-k 有序集合中的元素
+	heaps := []string{t.heap}
+	keys := make([]string, 0, len(sources))
+	for _, source := range sources {
+		heaps = append(heaps, fmt.Sprintf(topKHeapKeyFormat, source))
+		keys = append(keys, fmt.Sprintf(topKSketchKeyFormat, source))
+	}
+	var candidates []string
+	var seen = make(map[string]struct{})
+	for _, heap := range heaps {
+		members, err := t.c.ZRange(ctx, heap, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if _, ok := seen[m]; !ok {
+				seen[m] = struct{}{}
+				candidates = append(candidates, m)
+			}
+		}
+	}
+	if err := t.counts.mergeKeys(ctx, keys); err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 3+len(candidates)*int(t.depth+1))
+	args = append(args, t.depth, t.width, t.k)
+	for _, item := range candidates {
+		args = append(args, item)
+		args = append(args, t.cols(item)...)
+	}
+	return t.rebuildScript.Run(ctx, []string{t.key, t.heap}, args...).Err()
+}
+
+func (t *topK) Delete(ctx context.Context) error {
+	if err := t.c.Del(ctx, t.key).Err(); err != nil {
+		return err
+	}
+	return t.c.Del(ctx, t.heap).Err()
+}
+
+// NewTopK 新建一个 top-k，相同名称的 top-k 应使用相同的 k、Width 以及 Depth
+func (c *client) NewTopK(name string, k uint, opts ...TopKOption) (TopK, error) {
+	return newTopK(c, name, k, opts...)
+}